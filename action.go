@@ -3,32 +3,114 @@ package streamdeck
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
 )
 
 // Action represents a discrete action and acts as a action to createInstance instances.
 type Action interface {
 	ActionUUID() ActionUUID
-	InitializeAction(pluginUUID PluginUUID, publisher ActionPublisher)
+	InitializeAction(pluginUUID PluginUUID, info RegistrationInfo, publisher ActionPublisher)
+}
+
+// InstancedActionOption configures an InstancedAction.
+type InstancedActionOption func(*InstancedAction)
+
+// WithQueue makes the InstancedAction dispatch events asynchronously, giving each instance its own bounded queue
+// handled by a dedicated goroutine. Events for a single instance are handled in order while different instances
+// handle events concurrently, so one slow handler doesn't hold up the rest of the plugin. Errors returned by handlers
+// are logged. Without this option, events are handled synchronously on the goroutine reading from the device.
+func WithQueue(opts QueueOptions) InstancedActionOption {
+	return func(a *InstancedAction) {
+		a.queueOpts = &opts
+	}
+}
+
+// WithLogger sets the logger receiving the logs of the InstancedAction, such as errors returned by handlers of queued
+// events. Without this option, the InstancedAction uses the logger of its Plugin.
+func WithLogger(logger *slog.Logger) InstancedActionOption {
+	return func(a *InstancedAction) {
+		a.logger = logger
+	}
+}
+
+// WithoutReplay stops the InstancedAction from replaying the last title and image each instance published when it
+// appears again, for actions that always publish them when handling the streamdeckevent.WillAppear event.
+func WithoutReplay() InstancedActionOption {
+	return func(a *InstancedAction) {
+		a.visuals = nil
+	}
+}
+
+// WithManifest describes the entry of the InstancedAction in the manifest of the plugin. The UUID of the entry is
+// filled in from the action.
+func WithManifest(entry streamdeckmanifest.Action) InstancedActionOption {
+	return func(a *InstancedAction) {
+		a.manifest = &entry
+	}
 }
 
 // NewInstancedAction makes an implementation of a InstancedAction.
-func NewInstancedAction(actionUUID ActionUUID, createInstance ActionInstanceFactory) *InstancedAction {
-	return &InstancedAction{
-		actionUUID:     actionUUID,
-		createInstance: createInstance,
-		instances:      make(map[EventContext]ActionInstance),
+func NewInstancedAction(actionUUID ActionUUID, createInstance ActionInstanceFactory, opts ...InstancedActionOption) *InstancedAction {
+	a := &InstancedAction{
+		actionUUID: actionUUID,
+		create: func(eventContext EventContext, publisher ActionInstancePublisher) (ActionInstance, settingsDecoder) {
+			return createInstance(eventContext, publisher), nil
+		},
+		instances: make(map[EventContext]*instanceEntry),
+		retiring:  make(map[EventContext]*instanceEntry),
+		visuals:   make(map[EventContext]*visualCache),
+		pending:   newPendingEvents(),
 	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// InstancedActionProvider is implemented by Actions that manage ActionInstances, allowing a Plugin to look them up.
+type InstancedActionProvider interface {
+	Instance(eventContext EventContext) (ActionInstance, bool)
+	Instances() []ActionInstance
 }
 
 // InstancedAction implements the Action interface and delegates to a func for action instance creation, passing along
-// events to the appropriate instances.
+// events to the appropriate instances. An instance is created when the streamdeckevent.WillAppear event arrives for
+// its context and removed, after handling the streamdeckevent.WillDisappear event, when it disappears. Instances
+// implementing ActionInstanceDisposer are disposed of upon removal. With WithQueue, an instance appearing again for a
+// context handles its events only once the previous instance for the context has handled its last event and been
+// disposed of. Events for a context without an instance, such as those arriving after the streamdeckevent.WillDisappear
+// event, are logged at debug level and otherwise ignored.
+//
+// The last title and image an instance published through its ActionInstancePublisher are replayed when an instance
+// appears again for the same context, such as when switching back to a page, before the new instance handles the
+// streamdeckevent.WillAppear event. The state is not replayed, as the application remembers it and may have changed
+// it since; the streamdeckevent.WillAppear event carries the current one. WithoutReplay disables this.
+//
+// An InstancedAction is safe for concurrent use; Instance and Instances may be called from any goroutine, including
+// from within event handlers and instance factories.
 type InstancedAction struct {
-	actionUUID     ActionUUID
-	createInstance ActionInstanceFactory
-	instances      map[EventContext]ActionInstance
+	actionUUID ActionUUID
+	create     func(eventContext EventContext, publisher ActionInstancePublisher) (ActionInstance, settingsDecoder)
+	queueOpts  *QueueOptions
+	pending    *pendingEvents
+	logger     *slog.Logger
+	manifest   *streamdeckmanifest.Action
+
+	mu        sync.RWMutex
+	instances map[EventContext]*instanceEntry
+	retiring  map[EventContext]*instanceEntry
+	visuals   map[EventContext]*visualCache
 
 	pluginUUID PluginUUID
+	info       RegistrationInfo
 	publisher  ActionPublisher
 }
 
@@ -37,12 +119,35 @@ func (a *InstancedAction) ActionUUID() ActionUUID {
 	return a.actionUUID
 }
 
+// DescribeManifest implements the ManifestDescriber interface. An InstancedAction made without WithManifest leaves
+// its entry to the base manifest.
+func (a *InstancedAction) DescribeManifest() (streamdeckmanifest.Action, bool) {
+	if a.manifest == nil {
+		return streamdeckmanifest.Action{}, false
+	}
+
+	entry := *a.manifest
+	entry.UUID = a.actionUUID
+	return entry, true
+}
+
 // InitializeAction implements the Action interface.
-func (a *InstancedAction) InitializeAction(pluginUUID PluginUUID, publisher ActionPublisher) {
+func (a *InstancedAction) InitializeAction(pluginUUID PluginUUID, info RegistrationInfo, publisher ActionPublisher) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.pluginUUID = pluginUUID
+	a.info = info
 	a.publisher = publisher
 }
 
+// Info returns the RegistrationInfo the action was initialized with, for instance so instances can localize strings
+// or lay themselves out for the connected devices.
+func (a *InstancedAction) Info() RegistrationInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.info
+}
+
 // HandleEvent implements the streamdeckcore.Handler interface.
 func (a *InstancedAction) HandleEvent(ctx context.Context, raw json.RawMessage) error {
 	var eventHeader struct {
@@ -61,26 +166,369 @@ func (a *InstancedAction) HandleEvent(ctx context.Context, raw json.RawMessage)
 
 	// If the context is empty, the event is intended for all instances of this action.
 	if eventHeader.Context == "" {
-		for _, instance := range a.instances {
-			if err := dispatchEvent(ctx, instance, eventHeader.Event, raw); err != nil {
-				return fmt.Errorf("dispatching event %q to action instance %q: %w", eventHeader.Event, eventHeader.Context, err)
+		var errs []error
+		for _, entry := range a.entries() {
+			if err := entry.dispatchEvent(ctx, eventHeader.Event, raw); err != nil {
+				errs = append(errs, err)
 			}
 		}
 
+		return errors.Join(errs...)
+	}
+
+	var entry *instanceEntry
+	switch eventHeader.Event {
+	case streamdeckevent.WillAppearName:
+		entry = a.getOrCreateEntry(eventHeader.Context)
+	case streamdeckevent.WillDisappearName:
+		if entry = a.removeEntry(eventHeader.Context); entry == nil {
+			return nil
+		}
+	default:
+		a.mu.RLock()
+		entry = a.instances[eventHeader.Context]
+		a.mu.RUnlock()
+		if entry == nil {
+			a.log().Debug("ignoring event for unknown action instance",
+				slog.String("action", string(a.actionUUID)),
+				slog.String("context", string(eventHeader.Context)),
+				slog.String("event", string(eventHeader.Event)))
+			return nil
+		}
+	}
+
+	return entry.dispatchEvent(ctx, eventHeader.Event, raw)
+}
+
+// Instance implements the InstancedActionProvider interface. It returns the live instance for the context, if one
+// exists.
+func (a *InstancedAction) Instance(eventContext EventContext) (ActionInstance, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	entry, ok := a.instances[eventContext]
+	if !ok {
+		return nil, false
+	}
+
+	return entry.instance, true
+}
+
+// Instances implements the InstancedActionProvider interface. It returns a snapshot of the live instances in no
+// particular order.
+func (a *InstancedAction) Instances() []ActionInstance {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	instances := make([]ActionInstance, 0, len(a.instances))
+	for _, entry := range a.instances {
+		instances = append(instances, entry.instance)
+	}
+
+	return instances
+}
+
+// HandleDisconnected implements the streamdeckcore.DisconnectedHandler interface.
+func (a *InstancedAction) HandleDisconnected(ctx context.Context, err error) error {
+	var errs []error
+	for _, entry := range a.entries() {
+		if h, ok := entry.instance.(DisconnectedHandler); ok {
+			herr := entry.dispatch(ctx, "", func(ctx context.Context) error {
+				return h.HandleDisconnected(ctx, err)
+			})
+			if herr != nil {
+				errs = append(errs, fmt.Errorf("handling disconnect for action instance %q: %w", entry.eventContext, herr))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// HandleReconnected implements the streamdeckcore.ReconnectedHandler interface.
+func (a *InstancedAction) HandleReconnected(ctx context.Context) error {
+	var errs []error
+	for _, entry := range a.entries() {
+		if h, ok := entry.instance.(ReconnectedHandler); ok {
+			if err := entry.dispatch(ctx, "", h.HandleReconnected); err != nil {
+				errs = append(errs, fmt.Errorf("handling reconnect for action instance %q: %w", entry.eventContext, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Drain implements the streamdeckcore.Drainer interface. It waits until every queued event has been handled, then
+// discards the live instances, disposing of those implementing ActionInstanceDisposer, since the connection they
+// appeared on is gone. Once Drain returns, no goroutines of the InstancedAction remain.
+func (a *InstancedAction) Drain() {
+	a.pending.wait()
+
+	a.mu.Lock()
+	live := make([]*instanceEntry, 0, len(a.instances))
+	for eventContext, entry := range a.instances {
+		live = append(live, entry)
+		delete(a.instances, eventContext)
+	}
+	retiring := make([]*instanceEntry, 0, len(a.retiring))
+	for _, entry := range a.retiring {
+		retiring = append(retiring, entry)
+	}
+	a.mu.Unlock()
+
+	// The handler context has been cancelled by now.
+	ctx := context.Background()
+	for _, entry := range live {
+		if err := entry.dispatch(ctx, "", entry.dispose); err != nil {
+			entry.logger.Error("disposing action instance", slog.Any("error", err))
+		}
+
+		if entry.queue != nil {
+			entry.queue.close()
+		}
+	}
+
+	for _, entry := range append(live, retiring...) {
+		if entry.done != nil {
+			<-entry.done
+		}
+	}
+}
+
+func (a *InstancedAction) entries() []*instanceEntry {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	entries := make([]*instanceEntry, 0, len(a.instances))
+	for _, entry := range a.instances {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func (a *InstancedAction) getOrCreateEntry(eventContext EventContext) *instanceEntry {
+	a.mu.RLock()
+	entry, ok := a.instances[eventContext]
+	actionPublisher := a.publisher
+	a.mu.RUnlock()
+	if ok {
+		return entry
+	}
+
+	// The factory is called without holding the lock so that it may safely look up other instances.
+	publisher := newCoreActionInstancePublisher(eventContext, actionPublisher)
+	publisher.visuals = a.visualCache(eventContext)
+	logger := a.log().With(slog.String("action", string(a.actionUUID)), slog.String("context", string(eventContext)))
+	instance, settings := a.create(eventContext, publisher)
+	entry = &instanceEntry{
+		eventContext: eventContext,
+		instance:     instance,
+		publisher:    publisher,
+		settings:     settings,
+		visuals:      publisher.visuals,
+		logger:       logger,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if existing, ok := a.instances[eventContext]; ok {
+		return existing
+	}
+
+	if a.queueOpts != nil {
+		entry.queue = newEventQueue(*a.queueOpts, a.pending)
+		entry.done = make(chan struct{})
+
+		previous := a.retiring[eventContext]
+		delete(a.retiring, eventContext)
+		go entry.run(a, previous)
+	}
+
+	a.instances[eventContext] = entry
+	return entry
+}
+
+func (a *InstancedAction) inheritLogger(logger *slog.Logger) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.logger == nil {
+		a.logger = logger
+	}
+}
+
+func (a *InstancedAction) log() *slog.Logger {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.logger == nil {
+		return slog.Default()
+	}
+
+	return a.logger
+}
+
+// visualCache returns the cache of the visual state of the context, which outlives its instances, or nil when replay
+// is disabled.
+func (a *InstancedAction) visualCache(eventContext EventContext) *visualCache {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.visuals == nil {
 		return nil
 	}
 
-	// If the instance doesn't yet exist, create one and save it off.
-	instance, ok := a.instances[eventHeader.Context]
+	cache, ok := a.visuals[eventContext]
 	if !ok {
-		publisher := newCoreActionInstancePublisher(eventHeader.Context, a.publisher)
-		instance = a.createInstance(eventHeader.Context, publisher)
-		a.instances[eventHeader.Context] = instance
+		cache = &visualCache{}
+		a.visuals[eventContext] = cache
 	}
 
-	if err := dispatchEvent(ctx, instance, eventHeader.Event, raw); err != nil {
-		return fmt.Errorf("dispatching event %q to action instance %q: %w", eventHeader.Event, eventHeader.Context, err)
+	return cache
+}
+
+func (a *InstancedAction) removeEntry(eventContext EventContext) *instanceEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, ok := a.instances[eventContext]
+	if !ok {
+		return nil
+	}
+
+	delete(a.instances, eventContext)
+	if entry.queue != nil {
+		a.retiring[eventContext] = entry
+	}
+
+	return entry
+}
+
+// retired forgets the entry once its worker has handled its last event.
+func (a *InstancedAction) retired(entry *instanceEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.retiring[entry.eventContext] == entry {
+		delete(a.retiring, entry.eventContext)
 	}
+}
+
+// instanceEntry holds a live instance along with its publisher, its typed settings, the cache of its visual state,
+// and its queue, when events are dispatched asynchronously. The done channel is closed once the worker consuming the
+// queue has exited. Without a queue, mu serializes the handlers, since the connection handlers run alongside the
+// receipt of events.
+type instanceEntry struct {
+	eventContext EventContext
+	instance     ActionInstance
+	publisher    ActionInstancePublisher
+	settings     settingsDecoder
+	visuals      *visualCache
+	queue        *eventQueue
+	done         chan struct{}
+	logger       *slog.Logger
+	mu           sync.Mutex
+}
+
+// dispatchEvent hands the raw event to the instance. A streamdeckevent.WillDisappear event is the last event the
+// instance receives, after which it is disposed of.
+func (e *instanceEntry) dispatchEvent(ctx context.Context, eventName EventName, raw json.RawMessage) error {
+	err := e.dispatch(ctx, eventName, func(ctx context.Context) error {
+		if err := e.decodeSettings(eventName, raw); err != nil {
+			return fmt.Errorf("decoding settings of action instance %q: %w", e.eventContext, err)
+		}
+
+		// A failure to replay shouldn't keep the instance from handling the event, which may well publish anew.
+		if eventName == streamdeckevent.WillAppearName && e.visuals != nil {
+			if err := e.visuals.replay(e.publisher); err != nil {
+				e.logger.Error("replaying visual state", slog.Any("error", err))
+			}
+		}
+
+		var errs []error
+		if err := dispatchEvent(ctx, e.instance, eventName, raw); err != nil {
+			errs = append(errs, fmt.Errorf("dispatching event %q to action instance %q: %w", eventName, e.eventContext, err))
+		}
+
+		// The instance is gone whether or not it handled the event, and only now have the events queued before it,
+		// which may have started an animation, been handled.
+		if eventName == streamdeckevent.WillDisappearName {
+			errs = append(errs, e.dispose(ctx))
+		}
+
+		return errors.Join(errs...)
+	})
+
+	if e.queue != nil && eventName == streamdeckevent.WillDisappearName {
+		e.queue.close()
+	}
+
+	return err
+}
+
+// dispose stops the animation of the instance and disposes of it if it implements ActionInstanceDisposer.
+func (e *instanceEntry) dispose(ctx context.Context) error {
+	e.publisher.StopAnimation()
+
+	if d, ok := e.instance.(ActionInstanceDisposer); ok {
+		if err := d.Dispose(ctx); err != nil {
+			return fmt.Errorf("disposing action instance %q: %w", e.eventContext, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeSettings updates the typed settings, if any, from events carrying the instance's current settings.
+func (e *instanceEntry) decodeSettings(eventName EventName, raw json.RawMessage) error {
+	if e.settings == nil || (eventName != streamdeckevent.WillAppearName && eventName != streamdeckevent.DidReceiveSettingsName) {
+		return nil
+	}
+
+	var event struct {
+		Payload struct {
+			Settings json.RawMessage `json:"settings"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return fmt.Errorf("unmarshalling %s: %w", eventName, err)
+	}
+
+	return e.settings.Decode(event.Payload.Settings)
+}
+
+// dispatch calls handle immediately or, when the instance has a queue, once the events queued before it are handled.
+func (e *instanceEntry) dispatch(ctx context.Context, eventName EventName, handle func(context.Context) error) error {
+	if e.queue == nil {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		return handle(ctx)
+	}
+
+	e.queue.push(queuedEvent{
+		ctx:       ctx,
+		eventName: eventName,
+		handle:    handle,
+	})
 
 	return nil
 }
+
+// run handles the queued events until the queue is closed. When the context has a previous instance still handling
+// its last events, run waits for it to finish so the events of the context stay in order.
+func (e *instanceEntry) run(action *InstancedAction, previous *instanceEntry) {
+	defer close(e.done)
+	defer action.retired(e)
+
+	if previous != nil {
+		<-previous.done
+	}
+
+	for {
+		event, ok := e.queue.pop()
+		if !ok {
+			return
+		}
+
+		if err := event.handle(event.ctx); err != nil {
+			e.logger.Error("handling queued event", slog.String("event", string(event.eventName)), slog.Any("error", err))
+		}
+
+		e.queue.pending.done()
+	}
+}
@@ -1,5 +1,9 @@
 package streamdeck
 
+import (
+	"context"
+)
+
 // ActionInstanceFactory creates instances of an action.
 type ActionInstanceFactory func(eventContext EventContext, publisher ActionInstancePublisher) ActionInstance
 
@@ -9,3 +13,10 @@ type ActionInstance interface {
 	ActionUUID() ActionUUID
 	EventContext() EventContext
 }
+
+// ActionInstanceDisposer is optionally implemented by ActionInstances holding resources, such as goroutines or timers,
+// that must be released once the instance disappears. Dispose is called after the instance has handled the
+// streamdeckevent.WillDisappear event and been removed from its InstancedAction, or when the plugin shuts down.
+type ActionInstanceDisposer interface {
+	Dispose(ctx context.Context) error
+}
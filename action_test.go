@@ -0,0 +1,373 @@
+package streamdeck_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+const testActionUUID streamdeck.ActionUUID = "com.example.test"
+
+// eventLog records the events handled by recordingInstances, in the order they were handled.
+type eventLog struct {
+	mu      sync.Mutex
+	entries []string
+	changed chan struct{}
+}
+
+func newEventLog() *eventLog {
+	return &eventLog{changed: make(chan struct{})}
+}
+
+func (l *eventLog) add(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, fmt.Sprintf(format, args...))
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+func (l *eventLog) snapshot() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.entries...)
+}
+
+// filter returns the entries starting with prefix.
+func (l *eventLog) filter(prefix string) []string {
+	var filtered []string
+	for _, entry := range l.snapshot() {
+		if strings.HasPrefix(entry, prefix) {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered
+}
+
+// waitFor waits until the log holds count entries starting with prefix.
+func (l *eventLog) waitFor(t *testing.T, prefix string, count int) []string {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		l.mu.Lock()
+		changed := l.changed
+		l.mu.Unlock()
+
+		if filtered := l.filter(prefix); len(filtered) >= count {
+			return filtered
+		}
+
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("timed out waiting for %d entries starting with %q, got %v", count, prefix, l.snapshot())
+		}
+	}
+}
+
+// recordingInstance logs the events it handles as "<name>:<event>", with the State of key events as a sequence
+// number. Its streamdeckevent.KeyDown handler logs "<name>:waiting" and waits for gate, when set, so tests can hold up
+// an instance.
+type recordingInstance struct {
+	name         string
+	eventContext streamdeck.EventContext
+	log          *eventLog
+	gate         chan struct{}
+}
+
+func (i *recordingInstance) ActionUUID() streamdeck.ActionUUID {
+	return testActionUUID
+}
+
+func (i *recordingInstance) EventContext() streamdeck.EventContext {
+	return i.eventContext
+}
+
+func (i *recordingInstance) HandleWillAppear(_ context.Context, _ streamdeckevent.WillAppear) error {
+	i.log.add("%s:willAppear", i.name)
+	return nil
+}
+
+func (i *recordingInstance) HandleWillDisappear(_ context.Context, _ streamdeckevent.WillDisappear) error {
+	i.log.add("%s:willDisappear", i.name)
+	return nil
+}
+
+func (i *recordingInstance) HandleKeyDown(_ context.Context, event streamdeckevent.KeyDown) error {
+	if i.gate != nil {
+		i.log.add("%s:waiting:%d", i.name, event.Payload.State)
+		<-i.gate
+	}
+
+	i.log.add("%s:keyDown:%d", i.name, event.Payload.State)
+	return nil
+}
+
+func (i *recordingInstance) HandleKeyUp(_ context.Context, event streamdeckevent.KeyUp) error {
+	i.log.add("%s:keyUp:%d", i.name, event.Payload.State)
+	return nil
+}
+
+func (i *recordingInstance) Dispose(_ context.Context) error {
+	i.log.add("%s:dispose", i.name)
+	return nil
+}
+
+// newRecordingAction makes an InstancedAction whose instances record into log. Instances are named after their
+// context and the number of instances created for it so far, such as "key1#2".
+func newRecordingAction(log *eventLog, gate chan struct{}, opts ...streamdeck.InstancedActionOption) *streamdeck.InstancedAction {
+	var mu sync.Mutex
+	created := make(map[streamdeck.EventContext]int)
+
+	return streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, _ streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		mu.Lock()
+		created[eventContext]++
+		n := created[eventContext]
+		mu.Unlock()
+
+		return &recordingInstance{
+			name:         fmt.Sprintf("%s#%d", eventContext, n),
+			eventContext: eventContext,
+			log:          log,
+			gate:         gate,
+		}
+	}, opts...)
+}
+
+// servePlugin serves the plugin against a new Host until the test ends. The returned stop func shuts the plugin down
+// and waits for Serve to return.
+func servePlugin(t *testing.T, plugin streamdeckcore.Plugin) (*streamdecktest.Host, func()) {
+	t.Helper()
+
+	host := streamdecktest.NewHost()
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		_ = streamdeckcore.Serve(ctx, host.Config(), plugin)
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			host.Close()
+			<-served
+		})
+	}
+	t.Cleanup(stop)
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer waitCancel()
+	if err := host.WaitForRegistration(waitCtx, 1); err != nil {
+		t.Fatalf("waiting for registration: %v", err)
+	}
+
+	return host, stop
+}
+
+func mustSend(t *testing.T, host *streamdecktest.Host, event interface{}) {
+	t.Helper()
+
+	if err := host.Send(event); err != nil {
+		t.Fatalf("sending event: %v", err)
+	}
+}
+
+func keyDown(eventContext streamdeck.EventContext, seq int) interface{} {
+	return streamdecktest.NewKeyDown(testActionUUID, eventContext, streamdeckevent.KeyDownPayload{State: seq})
+}
+
+func keyUp(eventContext streamdeck.EventContext, seq int) interface{} {
+	return streamdecktest.NewKeyUp(testActionUUID, eventContext, streamdeckevent.KeyUpPayload{State: seq})
+}
+
+func willAppear(eventContext streamdeck.EventContext) interface{} {
+	return streamdecktest.NewWillAppear(testActionUUID, eventContext, streamdeckevent.WillAppearPayload{})
+}
+
+func willDisappear(eventContext streamdeck.EventContext) interface{} {
+	return streamdecktest.NewWillDisappear(testActionUUID, eventContext, streamdeckevent.WillDisappearPayload{})
+}
+
+// releaseOnCleanup returns a func that opens gate, and opens it when the test ends so a failing test does not leave
+// Drain waiting on a held instance. It must be called after servePlugin, so the gate opens before the plugin stops.
+func releaseOnCleanup(t *testing.T, gate chan struct{}) func() {
+	var once sync.Once
+	release := func() {
+		once.Do(func() { close(gate) })
+	}
+	t.Cleanup(release)
+
+	return release
+}
+
+// waitUntil polls cond until it returns true.
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// workerGoroutines counts the goroutines running the queue of an instance.
+func workerGoroutines() int {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return strings.Count(string(buf[:n]), ".(*instanceEntry).run(")
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+func TestInstancedActionReappearWaitsForDispose(t *testing.T) {
+	log := newEventLog()
+	gate := make(chan struct{})
+	action := newRecordingAction(log, gate, streamdeck.WithQueue(streamdeck.QueueOptions{}))
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+	release := releaseOnCleanup(t, gate)
+
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 1))
+	mustSend(t, host, willDisappear("key"))
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 2))
+
+	// The first instance is held up in its key handler until the second instance has been created.
+	waitUntil(t, func() bool {
+		instance, ok := action.Instance("key")
+		return ok && instance.(*recordingInstance).name == "key#2"
+	})
+	release()
+
+	got := log.waitFor(t, "key#", 8)
+	want := []string{
+		"key#1:willAppear",
+		"key#1:waiting:1",
+		"key#1:keyDown:1",
+		"key#1:willDisappear",
+		"key#1:dispose",
+		"key#2:willAppear",
+		"key#2:waiting:2",
+		"key#2:keyDown:2",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestInstancedActionDrainStopsWorkers(t *testing.T) {
+	log := newEventLog()
+	action := newRecordingAction(log, nil, streamdeck.WithQueue(streamdeck.QueueOptions{}))
+	host, stop := servePlugin(t, streamdeck.NewPlugin(action))
+
+	for _, eventContext := range []streamdeck.EventContext{"key1", "key2", "key3"} {
+		mustSend(t, host, willAppear(eventContext))
+		mustSend(t, host, keyDown(eventContext, 1))
+	}
+	log.waitFor(t, "key", 6)
+
+	stop()
+
+	if got := log.filter("key"); len(got) != 9 {
+		t.Fatalf("expected every instance to be disposed of, got %v", got)
+	}
+	if instances := action.Instances(); len(instances) != 0 {
+		t.Fatalf("expected no live instances after Drain, got %d", len(instances))
+	}
+	if n := workerGoroutines(); n != 0 {
+		t.Fatalf("expected no worker goroutines after Drain, got %d", n)
+	}
+}
+
+func TestInstancedActionConcurrentLookups(t *testing.T) {
+	const presses = 50
+
+	log := newEventLog()
+	action := newRecordingAction(log, nil)
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+
+	// Look up instances while they are being created, dispatched to, and removed.
+	done := make(chan struct{})
+	var lookups sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		lookups.Add(1)
+		go func() {
+			defer lookups.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				for _, instance := range action.Instances() {
+					_ = instance.EventContext()
+				}
+				_, _ = action.Instance("key")
+			}
+		}()
+	}
+
+	mustSend(t, host, willAppear("key"))
+	for seq := 0; seq < presses; seq++ {
+		mustSend(t, host, keyDown("key", seq))
+		mustSend(t, host, keyUp("key", seq))
+	}
+	mustSend(t, host, willDisappear("key"))
+	log.waitFor(t, "key#1:dispose", 1)
+
+	close(done)
+	lookups.Wait()
+
+	if got := log.filter("key#1:"); len(got) != 3+2*presses {
+		t.Fatalf("expected every event to be handled, got %v", got)
+	}
+	if instances := action.Instances(); len(instances) != 0 {
+		t.Fatalf("expected no live instances, got %d", len(instances))
+	}
+}
+
+func TestInstancedActionIgnoresEventsForUnknownInstances(t *testing.T) {
+	var logged bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logged, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	log := newEventLog()
+	action := newRecordingAction(log, nil, streamdeck.WithLogger(logger))
+	streamdecktest.NewRecorder().InitializeAction(action)
+
+	// The key is pressed before the instance for its context appears, as when the plugin starts late.
+	raw := streamdecktest.NewKeyDown(testActionUUID, "key", streamdeckevent.KeyDownPayload{State: 0})
+	if err := action.HandleEvent(context.Background(), raw); err != nil {
+		t.Fatalf("expected the event to be ignored, got %v", err)
+	}
+
+	if got := log.snapshot(); len(got) != 0 {
+		t.Fatalf("expected no instance to handle the event, got %v", got)
+	}
+	if _, ok := action.Instance("key"); ok {
+		t.Fatal("expected no instance to be created")
+	}
+	if !strings.Contains(logged.String(), "ignoring event for unknown action instance") {
+		t.Fatalf("expected the event to be logged at debug level, got %q", logged.String())
+	}
+}
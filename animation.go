@@ -0,0 +1,214 @@
+package streamdeck
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+const (
+	// DefaultMaxFrameRate is the number of animation frames per second a Plugin publishes across all of its keys when
+	// PluginOptions.MaxFrameRate is not positive.
+	DefaultMaxFrameRate = 30
+	// DefaultFrameDuration is how long a Frame without a Duration is shown.
+	DefaultFrameDuration = 100 * time.Millisecond
+)
+
+// errAnimationsNotSupported is returned when animating through a publisher without a frame scheduler.
+var errAnimationsNotSupported = errors.New("animations are not supported by this publisher")
+
+// Frame is a single image of an Animation.
+type Frame struct {
+	// Image is the image as a data URI, as for streamdeckevent.SetImagePayload.
+	Image streamdeckevent.Base64String
+	// Duration is how long the frame is shown. A Duration of 0 uses DefaultFrameDuration.
+	Duration time.Duration
+}
+
+// Animation is a sequence of frames shown on a key.
+type Animation struct {
+	Frames []Frame
+	// Loop restarts the animation after the last frame. Otherwise, the last frame remains shown.
+	Loop bool
+	// Plays is the number of times a looping animation plays in full before its last frame remains shown. A Plays of
+	// 0 loops until the animation is stopped.
+	Plays int
+	// Target indicates whether the frames are displayed on the hardware, the software, or both.
+	Target streamdeckevent.Target
+	// State is the state of the action to animate, or nil for all states.
+	State *int
+}
+
+func (a *Animation) frameDuration(i int) time.Duration {
+	if d := a.Frames[i].Duration; d > 0 {
+		return d
+	}
+
+	return DefaultFrameDuration
+}
+
+// frameAt returns the index of the frame shown after elapsed time and whether a non-looping animation has ended.
+func (a *Animation) frameAt(elapsed time.Duration) (int, bool) {
+	var total time.Duration
+	for i := range a.Frames {
+		total += a.frameDuration(i)
+	}
+
+	if elapsed >= total {
+		if !a.Loop || (a.Plays > 0 && elapsed >= total*time.Duration(a.Plays)) {
+			return len(a.Frames) - 1, true
+		}
+		elapsed %= total
+	}
+
+	for i := range a.Frames {
+		if elapsed < a.frameDuration(i) {
+			return i, false
+		}
+		elapsed -= a.frameDuration(i)
+	}
+
+	return len(a.Frames) - 1, false
+}
+
+func newAnimator(maxFrameRate int, logger *slog.Logger) *animator {
+	if maxFrameRate <= 0 {
+		maxFrameRate = DefaultMaxFrameRate
+	}
+
+	return &animator{
+		interval:   time.Second / time.Duration(maxFrameRate),
+		logger:     logger,
+		animations: make(map[EventContext]*runningAnimation),
+	}
+}
+
+// animator schedules the frames of every running animation of a Plugin. It publishes at most one frame per interval,
+// so the animated keys share a global frame budget. When it falls behind, an animation skips straight to the frame
+// it should be showing, and the animation waiting the longest is served first. It is safe for concurrent use.
+type animator struct {
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu         sync.Mutex
+	animations map[EventContext]*runningAnimation
+	running    bool
+}
+
+type runningAnimation struct {
+	animation Animation
+	publish   func(streamdeckevent.SetImagePayload) error
+	start     time.Time
+	shown     int
+	published time.Time
+}
+
+// start runs the animation for the context, replacing any animation already running for it.
+func (a *animator) start(eventContext EventContext, animation Animation, publish func(streamdeckevent.SetImagePayload) error) {
+	if len(animation.Frames) == 0 {
+		a.cancel(eventContext)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	a.animations[eventContext] = &runningAnimation{
+		animation: animation,
+		publish:   publish,
+		start:     now,
+		shown:     -1,
+		published: now,
+	}
+
+	if !a.running {
+		a.running = true
+		go a.run()
+	}
+}
+
+// cancel stops the animation for the context, leaving the frame last shown.
+func (a *animator) cancel(eventContext EventContext) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.animations, eventContext)
+}
+
+// cancelAll stops every animation.
+func (a *animator) cancelAll() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for eventContext := range a.animations {
+		delete(a.animations, eventContext)
+	}
+}
+
+// run publishes frames until no animations are running.
+func (a *animator) run() {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if !a.tick(now) {
+			return
+		}
+	}
+}
+
+// tick publishes the due frame of the animation that has waited the longest since it last published. It returns
+// false, stopping the scheduler, once no animations are running.
+func (a *animator) tick(now time.Time) bool {
+	a.mu.Lock()
+	if len(a.animations) == 0 {
+		a.running = false
+		a.mu.Unlock()
+		return false
+	}
+
+	var (
+		nextContext EventContext
+		next        *runningAnimation
+		nextFrame   int
+		nextEnded   bool
+	)
+	for eventContext, running := range a.animations {
+		frame, ended := running.animation.frameAt(now.Sub(running.start))
+		if frame == running.shown {
+			if ended {
+				delete(a.animations, eventContext)
+			}
+			continue
+		}
+
+		if next == nil || running.published.Before(next.published) {
+			nextContext, next, nextFrame, nextEnded = eventContext, running, frame, ended
+		}
+	}
+
+	if next == nil {
+		a.mu.Unlock()
+		return true
+	}
+
+	next.shown = nextFrame
+	next.published = now
+	if nextEnded {
+		delete(a.animations, nextContext)
+	}
+	a.mu.Unlock()
+
+	err := next.publish(streamdeckevent.SetImagePayload{
+		Image:  next.animation.Frames[nextFrame].Image,
+		Target: next.animation.Target,
+		State:  next.animation.State,
+	})
+	if err != nil {
+		a.logger.Error("publishing animation frame", slog.String("context", string(nextContext)), slog.Any("error", err))
+	}
+
+	return true
+}
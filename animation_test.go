@@ -0,0 +1,150 @@
+package streamdeck_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+// animatedInstance shows a still image and then plays its animation when its key is pressed, once gate, if any, is
+// closed.
+type animatedInstance struct {
+	eventContext streamdeck.EventContext
+	publisher    streamdeck.ActionInstancePublisher
+	animation    streamdeck.Animation
+	gate         chan struct{}
+}
+
+func (i *animatedInstance) ActionUUID() streamdeck.ActionUUID {
+	return testActionUUID
+}
+
+func (i *animatedInstance) EventContext() streamdeck.EventContext {
+	return i.eventContext
+}
+
+func (i *animatedInstance) HandleKeyDown(_ context.Context, _ streamdeckevent.KeyDown) error {
+	if i.gate != nil {
+		<-i.gate
+	}
+
+	if err := i.publisher.SetImage(streamdeckevent.SetImagePayload{Image: "still"}); err != nil {
+		return err
+	}
+
+	return i.publisher.Animate(i.animation)
+}
+
+// serveAnimation serves an action whose instance for "key" plays the animation once pressed.
+func serveAnimation(t *testing.T, animation streamdeck.Animation) *streamdecktest.Host {
+	t.Helper()
+
+	action := streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		return &animatedInstance{eventContext: eventContext, publisher: publisher, animation: animation}
+	})
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 0))
+
+	return host
+}
+
+// waitForSettledImages waits until no image has been published for the context for a while.
+func waitForSettledImages(t *testing.T, host *streamdecktest.Host) []streamdeckevent.SetImage {
+	t.Helper()
+
+	images := host.Images("key")
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		time.Sleep(200 * time.Millisecond)
+
+		latest := host.Images("key")
+		if len(latest) > 1 && len(latest) == len(images) {
+			return latest
+		}
+		images = latest
+	}
+
+	t.Fatalf("timed out waiting for the animation to end, got %d images", len(images))
+	return nil
+}
+
+func TestAnimationStopsAfterPlays(t *testing.T) {
+	host := serveAnimation(t, streamdeck.Animation{
+		Frames: []streamdeck.Frame{
+			{Image: "first", Duration: 50 * time.Millisecond},
+			{Image: "last", Duration: 50 * time.Millisecond},
+		},
+		Loop:  true,
+		Plays: 2,
+	})
+
+	images := waitForSettledImages(t, host)
+	if got := images[len(images)-1].Payload.Image; got != "last" {
+		t.Fatalf("expected the last frame to remain shown, got %q", got)
+	}
+}
+
+func TestAnimationFramesAreReplayed(t *testing.T) {
+	host := serveAnimation(t, streamdeck.Animation{
+		Frames: []streamdeck.Frame{
+			{Image: "first", Duration: 50 * time.Millisecond},
+			{Image: "last", Duration: 50 * time.Millisecond},
+		},
+	})
+	shown := len(waitForSettledImages(t, host))
+
+	// The key shows the frame the animation ended on when it appears again, rather than the image before it.
+	mustSend(t, host, willDisappear("key"))
+	mustSend(t, host, willAppear("key"))
+	waitUntil(t, func() bool {
+		return len(host.Images("key")) > shown
+	})
+	host.AssertImage(t, "key", "last")
+}
+
+func TestAnimationStopsOnceQueuedEventsAreHandled(t *testing.T) {
+	gate := make(chan struct{})
+	action := streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		return &animatedInstance{
+			eventContext: eventContext,
+			publisher:    publisher,
+			animation: streamdeck.Animation{
+				Frames: []streamdeck.Frame{
+					{Image: "first", Duration: 20 * time.Millisecond},
+					{Image: "last", Duration: 20 * time.Millisecond},
+				},
+				Loop: true,
+			},
+			gate: gate,
+		}
+	}, streamdeck.WithQueue(streamdeck.QueueOptions{}))
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+	release := releaseOnCleanup(t, gate)
+
+	// The key disappears while the press that starts the endless animation is still queued.
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 0))
+	mustSend(t, host, willDisappear("key"))
+	mustSend(t, host, willAppear("probe"))
+	waitUntil(t, func() bool {
+		_, ok := action.Instance("probe")
+		return ok
+	})
+	release()
+
+	// The still image goes out, but the animation is stopped once the key disappears, perhaps before its first frame.
+	var shown int
+	waitUntil(t, func() bool {
+		shown = len(host.Images("key"))
+		time.Sleep(200 * time.Millisecond)
+		return shown > 0 && len(host.Images("key")) == shown
+	})
+	if shown > 3 {
+		t.Fatalf("expected the animation to stop with the instance, got %d images", shown)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"debug/macho"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// fatAlignment is the alignment, as a power of 2, of the binaries within a universal binary. It matches the page
+// size of arm64.
+const fatAlignment = 14
+
+// writeUniversal combines thin Mach-O binaries for different architectures into a universal binary at path.
+func writeUniversal(path string, thinPaths ...string) error {
+	type thin struct {
+		header macho.FatArchHeader
+		data   []byte
+	}
+
+	thins := make([]thin, 0, len(thinPaths))
+	offset := uint32(8 + 20*len(thinPaths))
+	for _, thinPath := range thinPaths {
+		data, err := os.ReadFile(thinPath)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", thinPath, err)
+		}
+
+		f, err := macho.Open(thinPath)
+		if err != nil {
+			return fmt.Errorf("opening Mach-O %s: %w", thinPath, err)
+		}
+		cpu, subCPU := f.Cpu, f.SubCpu
+		_ = f.Close()
+
+		offset = align(offset, 1<<fatAlignment)
+		thins = append(thins, thin{
+			header: macho.FatArchHeader{
+				Cpu:    cpu,
+				SubCpu: subCPU,
+				Offset: offset,
+				Size:   uint32(len(data)),
+				Align:  fatAlignment,
+			},
+			data: data,
+		})
+		offset += uint32(len(data))
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return fmt.Errorf("creating universal binary: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	header := []uint32{macho.MagicFat, uint32(len(thins))}
+	for _, t := range thins {
+		header = append(header, uint32(t.header.Cpu), t.header.SubCpu, t.header.Offset, t.header.Size, t.header.Align)
+	}
+	if err = binary.Write(out, binary.BigEndian, header); err != nil {
+		return fmt.Errorf("writing universal binary: %w", err)
+	}
+
+	for _, t := range thins {
+		if _, err = out.Seek(int64(t.header.Offset), io.SeekStart); err != nil {
+			return fmt.Errorf("writing universal binary: %w", err)
+		}
+		if _, err = out.Write(t.data); err != nil {
+			return fmt.Errorf("writing universal binary: %w", err)
+		}
+	}
+
+	return out.Close()
+}
+
+func align(offset uint32, alignment uint32) uint32 {
+	return (offset + alignment - 1) &^ (alignment - 1)
+}
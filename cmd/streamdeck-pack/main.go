@@ -0,0 +1,73 @@
+// Command streamdeck-pack builds a plugin into a distributable .streamDeckPlugin file, from any platform and without
+// the Stream Deck application or Elgato's tooling.
+//
+// It cross-compiles the plugin package for windows/amd64 and for darwin/amd64 and darwin/arm64, combining the latter
+// into a universal binary. It then lays out the <uuid>.sdPlugin directory with the manifest, the images and property
+// inspectors the manifest refers to, and any extra assets, rewriting CodePathWin and CodePathMac to the built
+// binaries, and zips the directory into <uuid>.streamDeckPlugin.
+//
+// Usage:
+//
+//	streamdeck-pack [flags] [package directory]
+//
+// The package directory defaults to the current directory and must contain the manifest, unless -generate is given
+// for a plugin served with streamdeckutil.ServeWithManifest. Generating writes the manifest and the property
+// inspectors of the actions, such as those made with streamdeckinspector.Form, straight into the bundle. A property
+// inspector read from the package directory is copied along with the rest of its directory, so its scripts and
+// stylesheets are included, unless it sits at the root of the package. For example:
+//
+//	go run github.com/craiggwilson/go-streamdeck-sdk/cmd/streamdeck-pack -uuid com.craiggwilson.streamdeck.example ./examples/streamdeck-example
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stringsFlag is a flag that may be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.pluginUUID, "uuid", "", "plugin UUID, defaulting to the UUID of the manifest")
+	flag.StringVar(&opts.manifest, "manifest", "manifest.json", "path of the manifest, relative to the package directory, unless generating it")
+	flag.StringVar(&opts.name, "name", "", "name of the built binaries, defaulting to the CodePath of the manifest or the package directory name")
+	flag.StringVar(&opts.out, "o", ".", "directory to write the .streamDeckPlugin file to")
+	flag.StringVar(&opts.keep, "dir", "", "directory to lay out the .sdPlugin directory in and keep, instead of a temporary one")
+	flag.BoolVar(&opts.generate, "generate", false, "generate the manifest and property inspectors by running the package with -generate-manifest, instead of reading the manifest")
+	flag.Var((*stringsFlag)(&opts.assets), "asset", "extra file or directory to include, relative to the package directory; may be repeated")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [package directory]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch flag.NArg() {
+	case 0:
+		opts.pkg = "."
+	case 1:
+		opts.pkg = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	path, err := pack(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Println(path)
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckutil"
+)
+
+const (
+	pluginDirSuffix = ".sdPlugin"
+	bundleSuffix    = ".streamDeckPlugin"
+)
+
+type options struct {
+	pkg        string
+	manifest   string
+	pluginUUID string
+	name       string
+	out        string
+	keep       string
+	generate   bool
+	assets     []string
+}
+
+// target is a platform the plugin is built for.
+type target struct {
+	goos   string
+	goarch string
+}
+
+// macTargets are combined into a single universal binary.
+var macTargets = []target{{goos: "darwin", goarch: "amd64"}, {goos: "darwin", goarch: "arm64"}}
+
+var windowsTarget = target{goos: "windows", goarch: "amd64"}
+
+// pack builds the plugin, returning the path of the .streamDeckPlugin file.
+func pack(opts options) (string, error) {
+	dir := opts.keep
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "streamdeck-pack")
+		if err != nil {
+			return "", fmt.Errorf("creating temporary directory: %w", err)
+		}
+		defer func() {
+			_ = os.RemoveAll(tmp)
+		}()
+		dir = tmp
+	}
+
+	// A generated manifest is staged until its UUID names the plugin directory.
+	manifestPath := filepath.Join(opts.pkg, opts.manifest)
+	stagingDir := filepath.Join(dir, "generated")
+	if opts.generate {
+		if err := os.RemoveAll(stagingDir); err != nil {
+			return "", fmt.Errorf("cleaning %s: %w", stagingDir, err)
+		}
+		if err := os.MkdirAll(stagingDir, 0755); err != nil {
+			return "", fmt.Errorf("creating %s: %w", stagingDir, err)
+		}
+
+		manifestPath = filepath.Join(stagingDir, streamdeckmanifest.FileName)
+		if err := generate(opts.pkg, manifestPath); err != nil {
+			return "", err
+		}
+	}
+
+	m, err := streamdeckmanifest.ReadFile(manifestPath)
+	if err != nil {
+		return "", err
+	}
+
+	pluginUUID := opts.pluginUUID
+	if pluginUUID == "" {
+		pluginUUID = m.UUID
+	}
+	if pluginUUID == "" {
+		return "", fmt.Errorf("the manifest has no UUID, so -uuid is required")
+	}
+
+	pluginDir := filepath.Join(dir, pluginUUID+pluginDirSuffix)
+	if err = os.RemoveAll(pluginDir); err != nil {
+		return "", fmt.Errorf("cleaning %s: %w", pluginDir, err)
+	}
+	if opts.generate {
+		err = os.Rename(stagingDir, pluginDir)
+	} else {
+		err = os.MkdirAll(pluginDir, 0755)
+	}
+	if err != nil {
+		return "", fmt.Errorf("creating %s: %w", pluginDir, err)
+	}
+
+	name := opts.name
+	if name == "" {
+		name = strings.TrimSuffix(m.CodePath, ".exe")
+	}
+	if name == "" {
+		abs, err := filepath.Abs(opts.pkg)
+		if err != nil {
+			return "", fmt.Errorf("resolving package directory: %w", err)
+		}
+		name = filepath.Base(abs)
+	}
+
+	if err = buildBinaries(opts.pkg, pluginDir, dir, name); err != nil {
+		return "", err
+	}
+
+	if err = copyAssets(opts.pkg, pluginDir, m, opts.assets); err != nil {
+		return "", err
+	}
+
+	m.CodePath = ""
+	m.CodePathMac = name
+	m.CodePathWin = name + ".exe"
+	if err = streamdeckmanifest.WriteFile(filepath.Join(pluginDir, streamdeckmanifest.FileName), m); err != nil {
+		return "", err
+	}
+
+	if err = streamdeckmanifest.Validate(m, pluginDir); err != nil {
+		return "", fmt.Errorf("invalid manifest:\n%w", err)
+	}
+
+	if err = os.MkdirAll(opts.out, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", opts.out, err)
+	}
+
+	bundle := filepath.Join(opts.out, pluginUUID+bundleSuffix)
+	if err = zipDir(bundle, dir, pluginDir); err != nil {
+		return "", err
+	}
+
+	return bundle, nil
+}
+
+// buildBinaries cross-compiles the package into the plugin directory, using work for intermediate binaries.
+func buildBinaries(pkg string, pluginDir string, work string, name string) error {
+	if err := build(pkg, windowsTarget, filepath.Join(pluginDir, name+".exe")); err != nil {
+		return err
+	}
+
+	thinPaths := make([]string, 0, len(macTargets))
+	for _, t := range macTargets {
+		thinPath := filepath.Join(work, name+"-"+t.goarch)
+		if err := build(pkg, t, thinPath); err != nil {
+			return err
+		}
+		thinPaths = append(thinPaths, thinPath)
+	}
+
+	defer func() {
+		for _, thinPath := range thinPaths {
+			_ = os.Remove(thinPath)
+		}
+	}()
+
+	return writeUniversal(filepath.Join(pluginDir, name), thinPaths...)
+}
+
+// generate runs the package to generate its manifest and property inspectors at manifestPath, as
+// streamdeckutil.ServeWithManifest does for GenerateManifestFlag.
+func generate(pkg string, manifestPath string) error {
+	manifestPath, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return fmt.Errorf("resolving manifest path: %w", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", streamdeckutil.GenerateManifestFlag, manifestPath)
+	cmd.Dir = pkg
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return fmt.Errorf("generating manifest: %w", err)
+	}
+
+	return nil
+}
+
+// build compiles the package for the target. Cgo is disabled so that no cross-compiler is needed.
+func build(pkg string, t target, output string) error {
+	output, err := filepath.Abs(output)
+	if err != nil {
+		return fmt.Errorf("resolving output path: %w", err)
+	}
+
+	cmd := exec.Command("go", "build", "-trimpath", "-o", output, ".")
+	cmd.Dir = pkg
+	cmd.Env = append(os.Environ(), "GOOS="+t.goos, "GOARCH="+t.goarch, "CGO_ENABLED=0")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return fmt.Errorf("building for %s/%s: %w", t.goos, t.goarch, err)
+	}
+
+	return nil
+}
+
+// copyAssets copies the images and property inspectors the manifest refers to, along with the extra assets, from the
+// package directory into the plugin directory.
+func copyAssets(pkg string, pluginDir string, m streamdeckmanifest.Manifest, assets []string) error {
+	var paths []string
+	for _, image := range m.Images() {
+		files := streamdeckmanifest.ImageFiles(pkg, image)
+		if len(files) == 0 {
+			return fmt.Errorf("image %s not found in %s", image, pkg)
+		}
+		paths = append(paths, files...)
+	}
+
+	for _, inspector := range m.PropertyInspectors() {
+		// Inspectors generated along with the manifest are already in place.
+		if _, err := os.Stat(filepath.Join(pluginDir, filepath.FromSlash(inspector))); err == nil {
+			continue
+		}
+
+		if d := path.Dir(inspector); d != "." {
+			inspector = d
+		}
+		paths = append(paths, inspector)
+	}
+
+	paths = append(paths, assets...)
+
+	for _, p := range paths {
+		if err := copyPath(filepath.Join(pkg, filepath.FromSlash(p)), filepath.Join(pluginDir, filepath.FromSlash(p))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyPath copies a file, or a directory recursively.
+func copyPath(src string, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("copying %s: %w", src, err)
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		return copyFile(p, target)
+	})
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("copying %s: %w", src, err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	if err = os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("copying %s: %w", src, err)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("copying %s: %w", src, err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	if _, err = io.Copy(out, in); err != nil {
+		return fmt.Errorf("copying %s: %w", src, err)
+	}
+
+	return out.Close()
+}
+
+// zipDir zips the directory into path, naming entries relative to base. File modes are kept, so the macOS binary
+// stays executable.
+func zipDir(path string, base string, dir string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	zw := zip.NewWriter(out)
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			header.Name += "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+
+		w, err := zw.CreateHeader(header)
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("zipping %s: %w", dir, err)
+	}
+
+	if err = zw.Close(); err != nil {
+		return fmt.Errorf("zipping %s: %w", dir, err)
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,188 @@
+package streamdeck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+var (
+	_ streamdeckcore.Plugin              = (*DeviceRegistry)(nil)
+	_ streamdeckcore.DisconnectedHandler = (*DeviceRegistry)(nil)
+	_ streamdeckcore.ReconnectedHandler  = (*DeviceRegistry)(nil)
+)
+
+// Device is a device connected to the application, such as a Stream Deck XL or a Stream Deck +.
+type Device struct {
+	UUID DeviceUUID
+	streamdeckcore.DeviceInfo
+}
+
+// DeviceChange describes a device connecting to or disconnecting from the application.
+type DeviceChange struct {
+	Device    Device
+	Connected bool
+}
+
+// NewDeviceRegistry makes a DeviceRegistry. It must be attached to a Plugin with Plugin.Attach.
+func NewDeviceRegistry() *DeviceRegistry {
+	return &DeviceRegistry{
+		devices: make(map[DeviceUUID]Device),
+	}
+}
+
+// DeviceRegistry tracks the devices connected to the application. It is seeded with the devices in the
+// RegistrationInfo and kept current from the streamdeckevent.DeviceDidConnect and streamdeckevent.DeviceDidDisconnect
+// events, so actions can adapt to the device an instance appears on without handling those events themselves. The
+// devices are forgotten when the connection to the application is lost, and seeded again once it is restored. It is
+// safe for concurrent use.
+type DeviceRegistry struct {
+	mu         sync.RWMutex
+	devices    map[DeviceUUID]Device
+	registered []streamdeckcore.RegisteredDevice
+
+	subscribers subscribers[DeviceChange]
+}
+
+// Initialize implements the streamdeckcore.Plugin interface.
+func (r *DeviceRegistry) Initialize(_ PluginUUID, info RegistrationInfo, _ Publisher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.registered = info.Devices
+	r.devices = make(map[DeviceUUID]Device, len(info.Devices))
+	for _, device := range info.Devices {
+		r.devices[device.ID] = Device{
+			UUID:       device.ID,
+			DeviceInfo: device.DeviceInfo,
+		}
+	}
+}
+
+// HandleDisconnected implements the streamdeckcore.DisconnectedHandler interface. Every device is forgotten, and the
+// subscribers are notified of each, since none can be reached until the connection is restored.
+func (r *DeviceRegistry) HandleDisconnected(_ context.Context, _ error) error {
+	r.mu.Lock()
+	devices := r.devices
+	r.devices = make(map[DeviceUUID]Device, len(devices))
+	r.mu.Unlock()
+
+	for _, device := range sortDevices(devices) {
+		r.subscribers.notify(DeviceChange{Device: device, Connected: false})
+	}
+
+	return nil
+}
+
+// HandleReconnected implements the streamdeckcore.ReconnectedHandler interface. The registry is seeded again with the
+// devices in the RegistrationInfo. It runs alongside the receipt of events, so devices that have already connected
+// again are kept as reported by their streamdeckevent.DeviceDidConnect event.
+func (r *DeviceRegistry) HandleReconnected(_ context.Context) error {
+	r.mu.Lock()
+	seeded := make(map[DeviceUUID]Device, len(r.registered))
+	for _, registered := range r.registered {
+		if _, ok := r.devices[registered.ID]; ok {
+			continue
+		}
+
+		device := Device{
+			UUID:       registered.ID,
+			DeviceInfo: registered.DeviceInfo,
+		}
+		r.devices[device.UUID] = device
+		seeded[device.UUID] = device
+	}
+	r.mu.Unlock()
+
+	for _, device := range sortDevices(seeded) {
+		r.subscribers.notify(DeviceChange{Device: device, Connected: true})
+	}
+
+	return nil
+}
+
+// HandleEvent implements the streamdeckcore.Handler interface.
+func (r *DeviceRegistry) HandleEvent(_ context.Context, raw json.RawMessage) error {
+	var eventHeader struct {
+		Event EventName `json:"event"`
+	}
+	if err := json.Unmarshal(raw, &eventHeader); err != nil {
+		return fmt.Errorf("unmarshalling event header: %w", err)
+	}
+
+	switch eventHeader.Event {
+	case streamdeckevent.DeviceDidConnectName:
+		var event streamdeckevent.DeviceDidConnect
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DeviceDidConnectName, err)
+		}
+
+		device := Device{
+			UUID:       event.Device,
+			DeviceInfo: event.DeviceInfo,
+		}
+
+		r.mu.Lock()
+		r.devices[device.UUID] = device
+		r.mu.Unlock()
+
+		r.subscribers.notify(DeviceChange{Device: device, Connected: true})
+	case streamdeckevent.DeviceDidDisconnectName:
+		var event streamdeckevent.DeviceDidDisconnect
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DeviceDidDisconnectName, err)
+		}
+
+		r.mu.Lock()
+		device, ok := r.devices[event.Device]
+		delete(r.devices, event.Device)
+		r.mu.Unlock()
+
+		if !ok {
+			device = Device{UUID: event.Device}
+		}
+
+		r.subscribers.notify(DeviceChange{Device: device, Connected: false})
+	}
+
+	return nil
+}
+
+// Device returns the connected device with the UUID, if one exists.
+func (r *DeviceRegistry) Device(deviceUUID DeviceUUID) (Device, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	device, ok := r.devices[deviceUUID]
+	return device, ok
+}
+
+// Devices returns a snapshot of the connected devices, ordered by UUID.
+func (r *DeviceRegistry) Devices() []Device {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortDevices(r.devices)
+}
+
+// Subscribe registers fn to be called whenever a device connects or disconnects. fn is called on the goroutine
+// dispatching events and must not block. The returned func unsubscribes fn.
+func (r *DeviceRegistry) Subscribe(fn func(DeviceChange)) (unsubscribe func()) {
+	return r.subscribers.add(fn)
+}
+
+// sortDevices returns the devices ordered by UUID.
+func sortDevices(devices map[DeviceUUID]Device) []Device {
+	sorted := make([]Device, 0, len(devices))
+	for _, device := range devices {
+		sorted = append(sorted, device)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UUID < sorted[j].UUID
+	})
+
+	return sorted
+}
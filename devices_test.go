@@ -0,0 +1,140 @@
+package streamdeck_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+var (
+	deck = streamdeck.Device{
+		UUID:       "deck",
+		DeviceInfo: streamdeckcore.DeviceInfo{Type: streamdeckcore.StreamDeck, Size: streamdeckcore.DeviceSize{Columns: 5, Rows: 3}, DeviceName: "Stream Deck"},
+	}
+	plus = streamdeck.Device{
+		UUID:       "plus",
+		DeviceInfo: streamdeckcore.DeviceInfo{Type: streamdeckcore.StreamDeckPlus, Size: streamdeckcore.DeviceSize{Columns: 4, Rows: 2}, DeviceName: "Stream Deck +"},
+	}
+	xl = streamdeck.Device{
+		UUID:       "xl",
+		DeviceInfo: streamdeckcore.DeviceInfo{Type: streamdeckcore.StreamDeckXL, Size: streamdeckcore.DeviceSize{Columns: 8, Rows: 4}, DeviceName: "Stream Deck XL"},
+	}
+)
+
+// newDeviceRegistry attaches a DeviceRegistry to a plugin launched with the devices, recording the changes it sees.
+func newDeviceRegistry(t *testing.T, devices ...streamdeck.Device) (*streamdeck.Plugin, *streamdeck.DeviceRegistry, *[]streamdeck.DeviceChange) {
+	t.Helper()
+
+	var info streamdeck.RegistrationInfo
+	for _, device := range devices {
+		info.Devices = append(info.Devices, streamdeckcore.RegisteredDevice{ID: device.UUID, DeviceInfo: device.DeviceInfo})
+	}
+
+	registry := streamdeck.NewDeviceRegistry()
+	plugin := streamdeck.NewPlugin()
+	plugin.Attach(registry)
+	plugin.Initialize(streamdecktest.PluginUUID, info, streamdecktest.NewRecorder())
+
+	var changes []streamdeck.DeviceChange
+	t.Cleanup(registry.Subscribe(func(change streamdeck.DeviceChange) {
+		changes = append(changes, change)
+	}))
+
+	return plugin, registry, &changes
+}
+
+func assertDevices(t *testing.T, registry *streamdeck.DeviceRegistry, want ...streamdeck.Device) {
+	t.Helper()
+
+	if got := registry.Devices(); !reflect.DeepEqual(got, append([]streamdeck.Device{}, want...)) {
+		t.Fatalf("expected devices %+v, got %+v", want, got)
+	}
+}
+
+func assertChanges(t *testing.T, got []streamdeck.DeviceChange, want ...streamdeck.DeviceChange) {
+	t.Helper()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected changes %+v, got %+v", want, got)
+	}
+}
+
+func TestDeviceRegistrySeededFromRegistrationInfo(t *testing.T) {
+	_, registry, changes := newDeviceRegistry(t, plus, deck)
+
+	assertDevices(t, registry, deck, plus)
+	if device, ok := registry.Device("plus"); !ok || !reflect.DeepEqual(device, plus) {
+		t.Fatalf("expected %+v, got %+v", plus, device)
+	}
+	if _, ok := registry.Device("xl"); ok {
+		t.Fatal("expected the XL not to be connected")
+	}
+	assertChanges(t, *changes)
+}
+
+func TestDeviceRegistryTracksConnections(t *testing.T) {
+	plugin, registry, changes := newDeviceRegistry(t, deck)
+	ctx := context.Background()
+
+	if err := plugin.HandleEvent(ctx, streamdecktest.NewDeviceDidConnect(xl.UUID, xl.DeviceInfo)); err != nil {
+		t.Fatalf("handling event: %v", err)
+	}
+	assertDevices(t, registry, deck, xl)
+
+	if err := plugin.HandleEvent(ctx, streamdecktest.NewDeviceDidDisconnect(deck.UUID)); err != nil {
+		t.Fatalf("handling event: %v", err)
+	}
+	assertDevices(t, registry, xl)
+
+	// A device that was never seen is reported by its UUID alone.
+	if err := plugin.HandleEvent(ctx, streamdecktest.NewDeviceDidDisconnect("unknown")); err != nil {
+		t.Fatalf("handling event: %v", err)
+	}
+	assertDevices(t, registry, xl)
+
+	assertChanges(t, *changes,
+		streamdeck.DeviceChange{Device: xl, Connected: true},
+		streamdeck.DeviceChange{Device: deck, Connected: false},
+		streamdeck.DeviceChange{Device: streamdeck.Device{UUID: "unknown"}, Connected: false},
+	)
+}
+
+func TestDeviceRegistryResetOnReconnection(t *testing.T) {
+	plugin, registry, changes := newDeviceRegistry(t, deck, plus)
+	ctx := context.Background()
+
+	if err := plugin.HandleEvent(ctx, streamdecktest.NewDeviceDidConnect(xl.UUID, xl.DeviceInfo)); err != nil {
+		t.Fatalf("handling event: %v", err)
+	}
+
+	// No device can be reached while disconnected.
+	if err := plugin.HandleDisconnected(ctx, errors.New("connection lost")); err != nil {
+		t.Fatalf("handling disconnect: %v", err)
+	}
+	assertDevices(t, registry)
+
+	// The device that connected again before the reconnection was handled keeps what it reported.
+	renamed := plus
+	renamed.DeviceName = "Desk"
+	if err := plugin.HandleEvent(ctx, streamdecktest.NewDeviceDidConnect(renamed.UUID, renamed.DeviceInfo)); err != nil {
+		t.Fatalf("handling event: %v", err)
+	}
+	if err := plugin.HandleReconnected(ctx); err != nil {
+		t.Fatalf("handling reconnect: %v", err)
+	}
+	assertDevices(t, registry, deck, renamed)
+
+	assertChanges(t, *changes,
+		streamdeck.DeviceChange{Device: xl, Connected: true},
+		streamdeck.DeviceChange{Device: deck, Connected: false},
+		streamdeck.DeviceChange{Device: plus, Connected: false},
+		streamdeck.DeviceChange{Device: xl, Connected: false},
+		streamdeck.DeviceChange{Device: renamed, Connected: true},
+		streamdeck.DeviceChange{Device: deck, Connected: true},
+	)
+}
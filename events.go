@@ -19,6 +19,10 @@ type ApplicationDidTerminateHandler interface {
 	HandleApplicationDidTerminate(ctx context.Context, event streamdeckevent.ApplicationDidTerminate) error
 }
 
+// ConnectedHandler is implemented by Actions that wish to know when the plugin has registered with the device, both
+// initially and after each reconnection. It is an alias for streamdeckcore.ConnectedHandler.
+type ConnectedHandler = streamdeckcore.ConnectedHandler
+
 // DeviceDidConnectHandler is implemented by ActionInstances that wish to receive the streamdeckevent.DeviceDidConnect event.
 type DeviceDidConnectHandler interface {
 	HandleDeviceDidConnect(ctx context.Context, event streamdeckevent.DeviceDidConnect) error
@@ -29,6 +33,30 @@ type DeviceDidDisconnectHandler interface {
 	HandleDeviceDidDisconnect(ctx context.Context, event streamdeckevent.DeviceDidDisconnect) error
 }
 
+// DialDownHandler is implemented by ActionInstances that wish to receive the streamdeckevent.DialDown event.
+type DialDownHandler interface {
+	HandleDialDown(ctx context.Context, event streamdeckevent.DialDown) error
+}
+
+// DialPressHandler is implemented by ActionInstances that wish to receive the streamdeckevent.DialPress event.
+type DialPressHandler interface {
+	HandleDialPress(ctx context.Context, event streamdeckevent.DialPress) error
+}
+
+// DialRotateHandler is implemented by ActionInstances that wish to receive the streamdeckevent.DialRotate event.
+type DialRotateHandler interface {
+	HandleDialRotate(ctx context.Context, event streamdeckevent.DialRotate) error
+}
+
+// DialUpHandler is implemented by ActionInstances that wish to receive the streamdeckevent.DialUp event.
+type DialUpHandler interface {
+	HandleDialUp(ctx context.Context, event streamdeckevent.DialUp) error
+}
+
+// DisconnectedHandler is implemented by Actions and ActionInstances that wish to know when the connection to the device
+// has been lost. It is an alias for streamdeckcore.DisconnectedHandler.
+type DisconnectedHandler = streamdeckcore.DisconnectedHandler
+
 // DidReceiveSettingsHandler is implemented by ActionInstances that wish to receive the streamdeckevent.DidReceiveSettings event.
 type DidReceiveSettingsHandler interface {
 	HandleDidReceiveSettings(ctx context.Context, event streamdeckevent.DidReceiveSettings) error
@@ -59,6 +87,11 @@ type PropertyInspectorDidDisappearHandler interface {
 	HandlePropertyInspectorDidDisappear(ctx context.Context, event streamdeckevent.PropertyInspectorDidDisappear) error
 }
 
+// ReconnectedHandler is implemented by Actions and ActionInstances that wish to know when the connection to the device
+// has been re-established, for instance to re-publish titles and images. It is an alias for
+// streamdeckcore.ReconnectedHandler.
+type ReconnectedHandler = streamdeckcore.ReconnectedHandler
+
 // SendToPluginHandler is implemented by ActionInstances that wish to receive the streamdeckevent.SendToPlugin event.
 type SendToPluginHandler interface {
 	HandleSendToPlugin(ctx context.Context, event streamdeckevent.SendToPlugin) error
@@ -74,6 +107,11 @@ type TitleParametersDidChangeHandler interface {
 	HandleTitleParametersDidChange(ctx context.Context, event streamdeckevent.TitleParametersDidChange) error
 }
 
+// TouchTapHandler is implemented by ActionInstances that wish to receive the streamdeckevent.TouchTap event.
+type TouchTapHandler interface {
+	HandleTouchTap(ctx context.Context, event streamdeckevent.TouchTap) error
+}
+
 // WillAppearHandler is implemented by ActionInstances that wish to receive the streamdeckevent.WillAppear event.
 type WillAppearHandler interface {
 	HandleWillAppear(ctx context.Context, event streamdeckevent.WillAppear) error
@@ -134,6 +172,38 @@ func dispatchEvent(ctx context.Context, target interface{}, eventName streamdeck
 			}
 			return h.HandleDidReceiveGlobalSettings(ctx, event)
 		}
+	case streamdeckevent.DialDownName:
+		if h, ok := target.(DialDownHandler); ok {
+			var event streamdeckevent.DialDown
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DialDownName, err)
+			}
+			return h.HandleDialDown(ctx, event)
+		}
+	case streamdeckevent.DialPressName:
+		if h, ok := target.(DialPressHandler); ok {
+			var event streamdeckevent.DialPress
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DialPressName, err)
+			}
+			return h.HandleDialPress(ctx, event)
+		}
+	case streamdeckevent.DialRotateName:
+		if h, ok := target.(DialRotateHandler); ok {
+			var event streamdeckevent.DialRotate
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DialRotateName, err)
+			}
+			return h.HandleDialRotate(ctx, event)
+		}
+	case streamdeckevent.DialUpName:
+		if h, ok := target.(DialUpHandler); ok {
+			var event streamdeckevent.DialUp
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DialUpName, err)
+			}
+			return h.HandleDialUp(ctx, event)
+		}
 	case streamdeckevent.KeyDownName:
 		if h, ok := target.(KeyDownHandler); ok {
 			var event streamdeckevent.KeyDown
@@ -190,9 +260,16 @@ func dispatchEvent(ctx context.Context, target interface{}, eventName streamdeck
 			}
 			return h.HandleTitleParametersDidChange(ctx, event)
 		}
+	case streamdeckevent.TouchTapName:
+		if h, ok := target.(TouchTapHandler); ok {
+			var event streamdeckevent.TouchTap
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.TouchTapName, err)
+			}
+			return h.HandleTouchTap(ctx, event)
+		}
 	case streamdeckevent.WillAppearName:
 		if h, ok := target.(WillAppearHandler); ok {
-			fmt.Println("WILL APPEAR HANDLED")
 			var event streamdeckevent.WillAppear
 			if err := json.Unmarshal(raw, &event); err != nil {
 				return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.WillAppearName, err)
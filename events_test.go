@@ -0,0 +1,92 @@
+package streamdeck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+const encoderActionUUID streamdeck.ActionUUID = "com.example.encoder"
+
+// encoderInstance records the dial and touchscreen events it receives.
+type encoderInstance struct {
+	eventContext streamdeck.EventContext
+	received     []string
+}
+
+func (i *encoderInstance) ActionUUID() streamdeck.ActionUUID {
+	return encoderActionUUID
+}
+
+func (i *encoderInstance) EventContext() streamdeck.EventContext {
+	return i.eventContext
+}
+
+func (i *encoderInstance) HandleDialDown(_ context.Context, event streamdeckevent.DialDown) error {
+	i.received = append(i.received, fmt.Sprintf("%s:%s", event.Event, event.Payload.Controller))
+	return nil
+}
+
+func (i *encoderInstance) HandleDialUp(_ context.Context, event streamdeckevent.DialUp) error {
+	i.received = append(i.received, fmt.Sprintf("%s:%s", event.Event, event.Payload.Controller))
+	return nil
+}
+
+func (i *encoderInstance) HandleDialPress(_ context.Context, event streamdeckevent.DialPress) error {
+	i.received = append(i.received, fmt.Sprintf("%s:%t", event.Event, event.Payload.Pressed))
+	return nil
+}
+
+func (i *encoderInstance) HandleDialRotate(_ context.Context, event streamdeckevent.DialRotate) error {
+	i.received = append(i.received, fmt.Sprintf("%s:%d", event.Event, event.Payload.Ticks))
+	return nil
+}
+
+func (i *encoderInstance) HandleTouchTap(_ context.Context, event streamdeckevent.TouchTap) error {
+	i.received = append(i.received, fmt.Sprintf("%s:%v:%t", event.Event, event.Payload.TapPos, event.Payload.Hold))
+	return nil
+}
+
+func TestEncoderEventsAreDispatched(t *testing.T) {
+	var instance *encoderInstance
+	action := streamdeck.NewInstancedAction(encoderActionUUID, func(eventContext streamdeck.EventContext, _ streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		instance = &encoderInstance{eventContext: eventContext}
+		return instance
+	})
+	streamdecktest.NewRecorder().InitializeAction(action)
+
+	ctx := context.Background()
+	events := []json.RawMessage{
+		streamdecktest.NewWillAppear(encoderActionUUID, "dial", streamdeckevent.WillAppearPayload{Controller: streamdeckevent.Encoder}),
+		streamdecktest.NewDialDown(encoderActionUUID, "dial", streamdeckevent.DialDownPayload{Controller: streamdeckevent.Encoder}),
+		streamdecktest.NewDialRotate(encoderActionUUID, "dial", streamdeckevent.DialRotatePayload{Controller: streamdeckevent.Encoder, Ticks: -3}),
+		streamdecktest.NewDialUp(encoderActionUUID, "dial", streamdeckevent.DialUpPayload{Controller: streamdeckevent.Encoder}),
+		streamdecktest.NewDialPress(encoderActionUUID, "dial", streamdeckevent.DialPressPayload{Controller: streamdeckevent.Encoder, Pressed: true}),
+		streamdecktest.NewTouchTap(encoderActionUUID, "dial", streamdeckevent.TouchTapPayload{Controller: streamdeckevent.Encoder, TapPos: [2]int{76, 40}, Hold: true}),
+	}
+	for _, event := range events {
+		if err := action.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("handling %s: %v", event, err)
+		}
+	}
+
+	if instance == nil {
+		t.Fatal("expected an instance for the dial")
+	}
+	want := []string{
+		"dialDown:Encoder",
+		"dialRotate:-3",
+		"dialUp:Encoder",
+		"dialPress:true",
+		"touchTap:[76 40]:true",
+	}
+	if strings.Join(instance.received, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, instance.received)
+	}
+}
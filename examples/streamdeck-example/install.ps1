@@ -9,4 +9,5 @@ if (Test-Path $installDir)
 }
 
 go build -o "$installDir\go-streamdeck-sdk-example.exe" $srcDir
-Copy-Item "$srcDir\*.json" $installDir
+go run $srcDir -generate-manifest "$installDir\manifest.json"
+Copy-Item -Recurse "$srcDir\images" $installDir
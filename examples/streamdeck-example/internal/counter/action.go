@@ -6,26 +6,49 @@ import (
 
 	"github.com/craiggwilson/go-streamdeck-sdk"
 	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckinspector"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
 )
 
 const actionUUID = "com.craiggwilson.streamdeck.example.counter"
 
+// Settings are the persisted settings of a counter.
+type Settings struct {
+	Count int `json:"count" inspector:"Count,min=0"`
+}
+
 func New() *streamdeck.InstancedAction {
-	return streamdeck.NewInstancedAction(
+	return streamdeck.NewTypedInstancedAction(
 		actionUUID,
-		func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher, settings *streamdeck.Settings[Settings]) streamdeck.ActionInstance {
 			return &ActionInstance{
 				eventContext: eventContext,
 				publisher:    publisher,
+				settings:     settings,
 			}
 		},
+		streamdeck.WithManifest(streamdeckmanifest.Action{
+			Name:                  "Counter",
+			PropertyInspectorPath: "inspectors/counter/" + streamdeckinspector.FormPage,
+			PropertyInspector:     streamdeckinspector.MustForm[Settings](),
+			Icon:                  "images/action",
+			States: []streamdeckmanifest.State{{
+				Image:          "images/key",
+				TitleAlignment: streamdeckevent.Middle,
+				FontSize:       "9",
+			}},
+			SupportedInMultiActions: streamdeckmanifest.Bool(false),
+			Tooltip:                 "Increments a counter.",
+		}),
+		// The count is displayed whenever an instance appears, so there's nothing to replay.
+		streamdeck.WithoutReplay(),
 	)
 }
 
 type ActionInstance struct {
 	eventContext streamdeck.EventContext
 	publisher    streamdeck.ActionInstancePublisher
-	count        int
+	settings     *streamdeck.Settings[Settings]
 }
 
 func (a *ActionInstance) ActionUUID() streamdeck.ActionUUID {
@@ -36,10 +59,28 @@ func (a *ActionInstance) EventContext() streamdeck.EventContext {
 	return a.eventContext
 }
 
+func (a *ActionInstance) HandleWillAppear(_ context.Context, _ streamdeckevent.WillAppear) error {
+	return a.display()
+}
+
+func (a *ActionInstance) HandleDidReceiveSettings(_ context.Context, _ streamdeckevent.DidReceiveSettings) error {
+	// The count may have been edited in the property inspector.
+	return a.display()
+}
+
 func (a *ActionInstance) HandleKeyDown(_ context.Context, _ streamdeckevent.KeyDown) error {
-	a.count++
+	if err := a.settings.Update(func(s *Settings) {
+		s.Count++
+	}); err != nil {
+		return err
+	}
+
+	return a.display()
+}
+
+func (a *ActionInstance) display() error {
 	return a.publisher.SetTitle(streamdeckevent.SetTitlePayload{
-		Title:  strconv.Itoa(a.count),
+		Title:  strconv.Itoa(a.settings.Get().Count),
 		Target: streamdeckevent.HardwareAndSoftware,
 	})
 }
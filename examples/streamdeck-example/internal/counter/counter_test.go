@@ -0,0 +1,36 @@
+package counter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/examples/streamdeck-example/internal/counter"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+func TestCounter(t *testing.T) {
+	ctx := context.Background()
+	recorder := streamdecktest.NewRecorder()
+	action := counter.New()
+	recorder.InitializeAction(action)
+
+	events := []json.RawMessage{
+		streamdecktest.NewWillAppear(action.ActionUUID(), "key", streamdeckevent.WillAppearPayload{Settings: json.RawMessage(`{"count":41}`)}),
+		streamdecktest.NewKeyDown(action.ActionUUID(), "key", streamdeckevent.KeyDownPayload{}),
+	}
+	for _, event := range events {
+		if err := action.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("handling event: %v", err)
+		}
+	}
+
+	titles := recorder.Titles("key")
+	if len(titles) != 2 || titles[0].Payload.Title != "41" || titles[1].Payload.Title != "42" {
+		t.Fatalf("expected titles 41 and 42, got %v", titles)
+	}
+	if settings := recorder.Settings("key"); len(settings) != 1 || string(settings[0].Payload) != `{"count":42}` {
+		t.Fatalf("expected the count to be saved, got %v", settings)
+	}
+}
@@ -3,37 +3,54 @@ package synccounter
 import (
 	"context"
 	"strconv"
+	"sync"
 
 	"github.com/craiggwilson/go-streamdeck-sdk"
 	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
 )
 
 const actionUUID = "com.craiggwilson.streamdeck.example.synccounter"
 
 func New() *streamdeck.InstancedAction {
-	var instances []*ActionInstance
+	var action *streamdeck.InstancedAction
+	var mu sync.Mutex
 	var count int
 
 	increment := func() {
+		mu.Lock()
 		count++
-		for _, instance := range instances {
-			instance.display(count)
+		current := count
+		mu.Unlock()
+
+		for _, instance := range action.Instances() {
+			instance.(*ActionInstance).display(current)
 		}
 	}
 
-	return streamdeck.NewInstancedAction(
+	action = streamdeck.NewInstancedAction(
 		actionUUID,
 		func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
-			instance := &ActionInstance{
+			return &ActionInstance{
 				eventContext: eventContext,
 				publisher:    publisher,
 				inc:          increment,
 			}
-
-			instances = append(instances, instance)
-			return instance
 		},
+		streamdeck.WithManifest(streamdeckmanifest.Action{
+			Name: "Sync-Counter",
+			Icon: "images/action",
+			States: []streamdeckmanifest.State{{
+				Image:          "images/key",
+				TitleAlignment: streamdeckevent.Middle,
+				FontSize:       "9",
+			}},
+			SupportedInMultiActions: streamdeckmanifest.Bool(false),
+			Tooltip:                 "Increments a counter on all instances.",
+		}),
 	)
+
+	return action
 }
 
 type ActionInstance struct {
@@ -0,0 +1,39 @@
+package synccounter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/examples/streamdeck-example/internal/synccounter"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+func TestSyncCounter(t *testing.T) {
+	ctx := context.Background()
+	recorder := streamdecktest.NewRecorder()
+	action := synccounter.New()
+	recorder.InitializeAction(action)
+
+	events := []json.RawMessage{
+		streamdecktest.NewWillAppear(action.ActionUUID(), "key1", streamdeckevent.WillAppearPayload{}),
+		streamdecktest.NewWillAppear(action.ActionUUID(), "key2", streamdeckevent.WillAppearPayload{}),
+		streamdecktest.NewKeyDown(action.ActionUUID(), "key1", streamdeckevent.KeyDownPayload{}),
+		streamdecktest.NewKeyDown(action.ActionUUID(), "key2", streamdeckevent.KeyDownPayload{}),
+	}
+	for _, event := range events {
+		if err := action.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("handling event: %v", err)
+		}
+	}
+
+	// Pressing either key updates both.
+	for _, eventContext := range []streamdeck.EventContext{"key1", "key2"} {
+		titles := recorder.Titles(eventContext)
+		if len(titles) != 2 || titles[0].Payload.Title != "1" || titles[1].Payload.Title != "2" {
+			t.Fatalf("expected titles 1 and 2 on %s, got %v", eventContext, titles)
+		}
+	}
+}
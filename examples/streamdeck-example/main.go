@@ -1,16 +1,43 @@
 package main
 
+//go:generate go run . -generate-manifest manifest.json
+
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 
+	"github.com/craiggwilson/go-streamdeck-sdk"
 	"github.com/craiggwilson/go-streamdeck-sdk/examples/streamdeck-example/internal/counter"
 	"github.com/craiggwilson/go-streamdeck-sdk/examples/streamdeck-example/internal/synccounter"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
 	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckutil"
 )
 
+// manifest is completed with the entries the actions describe to generate manifest.json, along with the property
+// inspectors under inspectors/, which are not checked in.
+var manifest = streamdeckmanifest.Manifest{
+	SDKVersion:  2,
+	Author:      "Craig Wilson",
+	CodePath:    "go-streamdeck-sdk-example.exe",
+	Category:    "go-streamdeck-sdk Example",
+	Description: "Example plugin using the go-streamdeck-sdk.",
+	Icon:        "images/plugin",
+	Name:        "Example go-streamdeck-sdk Plugin",
+	URL:         "https://github.com/craiggwilson/go-streamdeck-sdk",
+	Version:     "0.1",
+	OS: []streamdeckmanifest.OS{
+		{Platform: streamdeckcore.PlatformMac, MinimumVersion: "10.11"},
+		{Platform: streamdeckcore.PlatformWindows, MinimumVersion: "10"},
+	},
+	Software: streamdeckmanifest.Software{
+		MinimumVersion: "4.1",
+	},
+}
+
 func main() {
 	lf, err := os.OpenFile(filepath.Join(os.TempDir(), "streamdeck-example.log"), os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
@@ -21,7 +48,10 @@ func main() {
 	}()
 
 	log.SetOutput(lf)
-	if err = streamdeckutil.Serve(context.Background(), os.Args, counter.New(), synccounter.New()); err != nil {
+	plugin := streamdeck.NewPlugin(counter.New(), synccounter.New())
+	if err = streamdeckutil.ServeWithManifest(context.Background(), os.Args, plugin, manifest); err != nil {
+		// Also report to the terminal when generating or validating the manifest.
+		fmt.Fprintln(os.Stderr, err)
 		log.Fatal(err.Error())
 	}
 }
@@ -0,0 +1,154 @@
+package streamdeck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+var (
+	_ streamdeckcore.Plugin           = (*GlobalSettings[struct{}])(nil)
+	_ streamdeckcore.ConnectedHandler = (*GlobalSettings[struct{}])(nil)
+)
+
+// NewGlobalSettings makes a GlobalSettings. It must be attached to a Plugin with Plugin.Attach.
+func NewGlobalSettings[T any]() *GlobalSettings[T] {
+	return &GlobalSettings[T]{}
+}
+
+// GlobalSettings holds the plugin's global settings decoded into T. It requests the global settings each time the
+// plugin connects and keeps the cached value current as streamdeckevent.DidReceiveGlobalSettings events arrive, so
+// actions and background goroutines can read them with Get and watch them with Subscribe. It is safe for concurrent
+// use.
+type GlobalSettings[T any] struct {
+	mu        sync.RWMutex
+	publisher ActionPublisher
+	value     T
+	// changes counts the changes to value, so a failed update only restores the value it replaced if nothing has
+	// changed it since.
+	changes uint64
+	// publishing is locked before mu is released to publish an update, so updates are written in the order they
+	// were made without holding up readers.
+	publishing sync.Mutex
+
+	subscribers subscribers[T]
+}
+
+// Initialize implements the streamdeckcore.Plugin interface.
+func (s *GlobalSettings[T]) Initialize(pluginUUID PluginUUID, _ RegistrationInfo, publisher Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publisher = newCoreActionPublisher(pluginUUID, "", publisher, nil, nil)
+}
+
+// HandleConnected implements the streamdeckcore.ConnectedHandler interface. It requests the global settings, which
+// arrive later as a streamdeckevent.DidReceiveGlobalSettings event.
+func (s *GlobalSettings[T]) HandleConnected(_ context.Context) error {
+	s.mu.RLock()
+	publisher := s.publisher
+	s.mu.RUnlock()
+
+	return publisher.GetGlobalSettings()
+}
+
+// HandleEvent implements the streamdeckcore.Handler interface.
+func (s *GlobalSettings[T]) HandleEvent(_ context.Context, raw json.RawMessage) error {
+	var eventHeader struct {
+		Event EventName `json:"event"`
+	}
+	if err := json.Unmarshal(raw, &eventHeader); err != nil {
+		return fmt.Errorf("unmarshalling event header: %w", err)
+	}
+
+	if eventHeader.Event != streamdeckevent.DidReceiveGlobalSettingsName {
+		return nil
+	}
+
+	var event streamdeckevent.DidReceiveGlobalSettings
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DidReceiveGlobalSettingsName, err)
+	}
+
+	value, err := unmarshalSettings[T](event.Payload.Settings)
+	if err != nil {
+		return fmt.Errorf("decoding global settings: %w", err)
+	}
+
+	s.mu.Lock()
+	s.value = value
+	s.changes++
+	s.mu.Unlock()
+
+	s.subscribers.notify(value)
+	return nil
+}
+
+// Get returns the current global settings. The value is a shallow copy, so maps and slices within it must not be
+// modified; use Update instead.
+func (s *GlobalSettings[T]) Get() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.value
+}
+
+// Set replaces the global settings and persists them with the device.
+func (s *GlobalSettings[T]) Set(value T) error {
+	return s.Update(func(v *T) {
+		*v = value
+	})
+}
+
+// Update modifies a copy of the global settings with fn and persists the result with the device. The current global
+// settings change right away, so concurrent updates build on one another, and are restored should writing the
+// streamdeckevent.SetGlobalSettings event to the connection fail. Subscribers are only notified once it has been
+// written; the device doesn't acknowledge it, so this doesn't mean the settings have been stored.
+func (s *GlobalSettings[T]) Update(fn func(*T)) error {
+	s.mu.Lock()
+	if s.publisher == nil {
+		s.mu.Unlock()
+		return errors.New("updating global settings: not attached to an initialized plugin")
+	}
+
+	previous := s.value
+	value := previous
+	fn(&value)
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("marshalling global settings: %w", err)
+	}
+
+	publisher := s.publisher
+	s.value = value
+	s.changes++
+	changes := s.changes
+	s.publishing.Lock()
+	s.mu.Unlock()
+
+	defer s.publishing.Unlock()
+	if err = publisher.SetGlobalSettings(raw); err != nil {
+		s.mu.Lock()
+		if s.changes == changes {
+			s.value = previous
+			s.changes++
+		}
+		s.mu.Unlock()
+		return err
+	}
+
+	s.subscribers.notify(value)
+	return nil
+}
+
+// Subscribe registers fn to be called with the new global settings whenever they change, whether they were received
+// from the device or updated by the plugin. fn is called on the goroutine that observed the change and must not block.
+// The returned func unsubscribes fn.
+func (s *GlobalSettings[T]) Subscribe(fn func(T)) (unsubscribe func()) {
+	return s.subscribers.add(fn)
+}
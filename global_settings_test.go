@@ -0,0 +1,87 @@
+package streamdeck_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+type theme struct {
+	Name string `json:"name"`
+}
+
+// gatedPublisher waits for its gate before each write, failing it with err.
+type gatedPublisher struct {
+	gate chan struct{}
+	err  error
+}
+
+func (p *gatedPublisher) PublishEvent(_ json.RawMessage) error {
+	<-p.gate
+	return p.err
+}
+
+func newGlobalSettings(publisher streamdeck.Publisher) (*streamdeck.GlobalSettings[theme], *[]theme) {
+	settings := streamdeck.NewGlobalSettings[theme]()
+	plugin := streamdeck.NewPlugin()
+	plugin.Attach(settings)
+	plugin.Initialize(streamdecktest.PluginUUID, streamdeck.RegistrationInfo{}, publisher)
+
+	var notified []theme
+	settings.Subscribe(func(value theme) {
+		notified = append(notified, value)
+	})
+
+	return settings, &notified
+}
+
+func TestGlobalSettingsUpdateDoesNotHoldReaders(t *testing.T) {
+	publisher := &gatedPublisher{gate: make(chan struct{})}
+	settings, notified := newGlobalSettings(publisher)
+
+	updated := make(chan error, 1)
+	go func() {
+		updated <- settings.Set(theme{Name: "dark"})
+	}()
+
+	// The update is visible while it is being written.
+	waitUntil(t, func() bool {
+		return settings.Get().Name == "dark"
+	})
+	if len(*notified) != 0 {
+		t.Fatalf("expected no notification before the write, got %v", *notified)
+	}
+
+	close(publisher.gate)
+	if err := <-updated; err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(*notified) != 1 || (*notified)[0].Name != "dark" {
+		t.Fatalf("expected a notification of the update, got %v", *notified)
+	}
+}
+
+func TestGlobalSettingsFailedUpdateIsRestored(t *testing.T) {
+	publisher := &gatedPublisher{gate: make(chan struct{})}
+	close(publisher.gate)
+	settings, notified := newGlobalSettings(publisher)
+
+	if err := settings.Set(theme{Name: "light"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	publisher.err = errors.New("connection lost")
+	if err := settings.Set(theme{Name: "dark"}); !errors.Is(err, publisher.err) {
+		t.Fatalf("expected the write to fail, got %v", err)
+	}
+
+	if got := settings.Get().Name; got != "light" {
+		t.Fatalf("expected the settings to be restored, got %q", got)
+	}
+	if len(*notified) != 1 {
+		t.Fatalf("expected only the successful update to be notified, got %v", *notified)
+	}
+}
@@ -1,5 +1,10 @@
 module github.com/craiggwilson/go-streamdeck-sdk
 
-go 1.15
+go 1.21
 
-require github.com/gorilla/websocket v1.4.2
+require (
+	github.com/gorilla/websocket v1.4.2
+	golang.org/x/image v0.18.0
+)
+
+require golang.org/x/text v0.16.0 // indirect
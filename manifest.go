@@ -0,0 +1,99 @@
+package streamdeck
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
+)
+
+// ManifestDescriber is implemented by Actions that may describe their own entry in the manifest of the plugin, such as
+// an InstancedAction. DescribeManifest returns false when the action leaves its entry to the base manifest.
+type ManifestDescriber interface {
+	DescribeManifest() (streamdeckmanifest.Action, bool)
+}
+
+// Manifest completes the base manifest with an entry for each of the plugin's actions, in the order they were
+// registered. Entries described by an action implementing ManifestDescriber replace those of base with the same UUID,
+// while the other actions keep their entries from base. It fails when an action has no entry, or when base has an
+// entry for an action that isn't registered, so the manifest and the code can't drift apart.
+func (p *Plugin) Manifest(base streamdeckmanifest.Manifest) (streamdeckmanifest.Manifest, error) {
+	entries := make(map[ActionUUID]streamdeckmanifest.Action, len(base.Actions))
+	for _, entry := range base.Actions {
+		if _, ok := p.actions[entry.UUID]; !ok {
+			return streamdeckmanifest.Manifest{}, fmt.Errorf("manifest describes unregistered action %s", entry.UUID)
+		}
+		entries[entry.UUID] = entry
+	}
+
+	m := base
+	m.Actions = make([]streamdeckmanifest.Action, 0, len(p.actions))
+	seen := make(map[ActionUUID]struct{}, len(p.actions))
+	for _, action := range p.ordered {
+		actionUUID := action.ActionUUID()
+		if _, ok := seen[actionUUID]; ok {
+			continue
+		}
+		seen[actionUUID] = struct{}{}
+
+		// The last action registered with a UUID is the one handling its events.
+		action = p.actions[actionUUID]
+
+		entry, ok := entries[actionUUID]
+		if d, isDescriber := action.(ManifestDescriber); isDescriber {
+			if described, isDescribed := d.DescribeManifest(); isDescribed {
+				entry, ok = described, true
+				entry.UUID = actionUUID
+			}
+		}
+
+		if !ok {
+			return streamdeckmanifest.Manifest{}, fmt.Errorf("action %s has no manifest entry", actionUUID)
+		}
+
+		m.Actions = append(m.Actions, entry)
+	}
+
+	return m, nil
+}
+
+// ValidateManifest cross-checks the manifest with the plugin's actions. Every registered action must have an entry and
+// every entry must be a registered action, since events for an action missing from either side are never handled.
+// Entries of actions implementing ManifestDescriber must also have the number of states the action describes, so a
+// manifest generated from an older description is caught. Every problem found is reported in the returned error.
+func (p *Plugin) ValidateManifest(m streamdeckmanifest.Manifest) error {
+	var problems []error
+
+	entries := make(map[ActionUUID]streamdeckmanifest.Action, len(m.Actions))
+	for _, entry := range m.Actions {
+		entries[entry.UUID] = entry
+		if _, ok := p.actions[entry.UUID]; !ok {
+			problems = append(problems, fmt.Errorf("action %s: in the manifest but not registered with the plugin", entry.UUID))
+		}
+	}
+
+	seen := make(map[ActionUUID]struct{}, len(p.actions))
+	for _, action := range p.ordered {
+		actionUUID := action.ActionUUID()
+		if _, ok := seen[actionUUID]; ok {
+			continue
+		}
+		seen[actionUUID] = struct{}{}
+
+		entry, ok := entries[actionUUID]
+		if !ok {
+			problems = append(problems, fmt.Errorf("action %s: registered with the plugin but not in the manifest", actionUUID))
+			continue
+		}
+
+		d, isDescriber := p.actions[actionUUID].(ManifestDescriber)
+		if !isDescriber {
+			continue
+		}
+		if described, isDescribed := d.DescribeManifest(); isDescribed && len(described.States) != len(entry.States) {
+			problems = append(problems, fmt.Errorf("action %s: has %d states in the manifest but describes %d", actionUUID, len(entry.States), len(described.States)))
+		}
+	}
+
+	return errors.Join(problems...)
+}
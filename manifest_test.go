@@ -0,0 +1,189 @@
+package streamdeck_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
+)
+
+// plainAction is an Action that doesn't describe its manifest entry.
+type plainAction streamdeck.ActionUUID
+
+func (a plainAction) ActionUUID() streamdeck.ActionUUID {
+	return streamdeck.ActionUUID(a)
+}
+
+func (a plainAction) InitializeAction(_ streamdeck.PluginUUID, _ streamdeck.RegistrationInfo, _ streamdeck.ActionPublisher) {
+}
+
+// describedAction is an InstancedAction describing its own manifest entry.
+func describedAction(actionUUID streamdeck.ActionUUID, entry streamdeckmanifest.Action) *streamdeck.InstancedAction {
+	return streamdeck.NewInstancedAction(actionUUID, func(eventContext streamdeck.EventContext, _ streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		return &stateInstance{eventContext: eventContext}
+	}, streamdeck.WithManifest(entry))
+}
+
+func TestPluginManifest(t *testing.T) {
+	plugin := streamdeck.NewPlugin(
+		describedAction("com.example.counter", streamdeckmanifest.Action{
+			Name:   "Counter",
+			Icon:   "images/counter",
+			States: []streamdeckmanifest.State{{Image: "images/counter"}},
+		}),
+		plainAction("com.example.toggle"),
+	)
+
+	base := streamdeckmanifest.Manifest{
+		Name:    "Example",
+		Version: "1.0.0",
+		Actions: []streamdeckmanifest.Action{
+			{
+				UUID:   "com.example.toggle",
+				Name:   "Toggle",
+				Icon:   "images/toggle",
+				States: []streamdeckmanifest.State{{Image: "images/off"}, {Image: "images/on"}},
+			},
+			{
+				UUID:   "com.example.counter",
+				Name:   "Old Counter",
+				States: []streamdeckmanifest.State{{Image: "images/old"}},
+			},
+		},
+	}
+
+	got, err := plugin.Manifest(base)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The described entry replaces the one in the base manifest, and the entries follow the order of registration.
+	want := streamdeckmanifest.Manifest{
+		Name:    "Example",
+		Version: "1.0.0",
+		Actions: []streamdeckmanifest.Action{
+			{
+				UUID:   "com.example.counter",
+				Name:   "Counter",
+				Icon:   "images/counter",
+				States: []streamdeckmanifest.State{{Image: "images/counter"}},
+			},
+			{
+				UUID:   "com.example.toggle",
+				Name:   "Toggle",
+				Icon:   "images/toggle",
+				States: []streamdeckmanifest.State{{Image: "images/off"}, {Image: "images/on"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	if len(base.Actions) != 2 || base.Actions[0].UUID != "com.example.toggle" {
+		t.Fatalf("expected the base manifest to be left alone, got %+v", base.Actions)
+	}
+}
+
+func TestPluginManifestErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		actions []streamdeck.Action
+		base    []streamdeckmanifest.Action
+		err     string
+	}{
+		{
+			name:    "undescribed action without entry",
+			actions: []streamdeck.Action{plainAction("com.example.toggle")},
+			err:     "action com.example.toggle has no manifest entry",
+		},
+		{
+			name:    "entry without action",
+			actions: []streamdeck.Action{plainAction("com.example.toggle")},
+			base: []streamdeckmanifest.Action{
+				{UUID: "com.example.toggle", Name: "Toggle"},
+				{UUID: "com.example.removed", Name: "Removed"},
+			},
+			err: "manifest describes unregistered action com.example.removed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := streamdeck.NewPlugin(tc.actions...).Manifest(streamdeckmanifest.Manifest{Actions: tc.base})
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestPluginValidateManifest(t *testing.T) {
+	counter := streamdeckmanifest.Action{
+		UUID:   "com.example.counter",
+		Name:   "Counter",
+		States: []streamdeckmanifest.State{{Image: "images/counter"}},
+	}
+	toggle := streamdeckmanifest.Action{
+		UUID:   "com.example.toggle",
+		Name:   "Toggle",
+		States: []streamdeckmanifest.State{{Image: "images/off"}, {Image: "images/on"}},
+	}
+	plugin := streamdeck.NewPlugin(describedAction("com.example.counter", counter), plainAction("com.example.toggle"))
+
+	testCases := []struct {
+		name    string
+		entries []streamdeckmanifest.Action
+		errs    []string
+	}{
+		{
+			name:    "matching",
+			entries: []streamdeckmanifest.Action{counter, toggle},
+		},
+		{
+			name:    "action missing from the manifest",
+			entries: []streamdeckmanifest.Action{counter},
+			errs:    []string{"action com.example.toggle: registered with the plugin but not in the manifest"},
+		},
+		{
+			name: "action missing from the plugin",
+			entries: []streamdeckmanifest.Action{counter, toggle, {
+				UUID:   "com.example.removed",
+				Name:   "Removed",
+				States: []streamdeckmanifest.State{{Image: "images/removed"}},
+			}},
+			errs: []string{"action com.example.removed: in the manifest but not registered with the plugin"},
+		},
+		{
+			name:    "actions missing from both",
+			entries: []streamdeckmanifest.Action{toggle, {UUID: "com.example.removed", Name: "Removed"}},
+			errs: []string{
+				"action com.example.removed: in the manifest but not registered with the plugin",
+				"action com.example.counter: registered with the plugin but not in the manifest",
+			},
+		},
+		{
+			name:    "stale states",
+			entries: []streamdeckmanifest.Action{{UUID: counter.UUID, Name: counter.Name, States: toggle.States}, toggle},
+			errs:    []string{"action com.example.counter: has 2 states in the manifest but describes 1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := plugin.ValidateManifest(streamdeckmanifest.Manifest{Actions: tc.entries})
+			if len(tc.errs) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != strings.Join(tc.errs, "\n") {
+				t.Fatalf("expected errors %q, got %v", tc.errs, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package streamdeck
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// OutboundOptions configures how a Plugin publishes the streamdeckevent.SetTitle, streamdeckevent.SetImage, and
+// streamdeckevent.SetState events that make up the face of a key. An event identical to the last one delivered for
+// the same context is skipped. Once an instance appears again or the plugin reconnects, its events are delivered
+// again regardless, as is a streamdeckevent.SetState once its key has been pressed.
+type OutboundOptions struct {
+	// MaxPerSecond caps the number of those events published per second across all contexts. Events waiting for
+	// their turn are coalesced, so only the latest of each event for a context is published. A MaxPerSecond of 0
+	// publishes every event immediately.
+	MaxPerSecond int
+}
+
+// outboundKey identifies the part of a key's face that an event updates. A streamdeckevent.SetTitle or
+// streamdeckevent.SetImage for one state or target doesn't supersede one for another, so they are told apart.
+type outboundKey struct {
+	eventName    EventName
+	eventContext EventContext
+	target       streamdeckevent.Target
+	state        int
+}
+
+// allStates is the state of an outboundKey for an event that applies to every state.
+const allStates = -1
+
+func newOutboundPublisher(opts OutboundOptions, logger *slog.Logger) *outboundPublisher {
+	p := &outboundPublisher{
+		logger:    logger,
+		delivered: make(map[outboundKey]string),
+		pending:   make(map[outboundKey]json.RawMessage),
+	}
+
+	if opts.MaxPerSecond > 0 {
+		p.interval = time.Second / time.Duration(opts.MaxPerSecond)
+	}
+
+	return p
+}
+
+// outboundPublisher wraps the Publisher of a Plugin to skip redundant events and rate-limit the rest. Events that
+// don't affect the face of a key pass straight through. It is safe for concurrent use.
+type outboundPublisher struct {
+	publisher Publisher
+	interval  time.Duration
+	logger    *slog.Logger
+
+	// delivering is locked before mu is released to publish an event, so events are written in the order delivered
+	// records them.
+	delivering sync.Mutex
+
+	mu        sync.Mutex
+	delivered map[outboundKey]string
+	pending   map[outboundKey]json.RawMessage
+	order     []outboundKey
+	next      time.Time
+	flushing  bool
+}
+
+// PublishEvent implements the Publisher interface. Coalesced events are published in the background, where errors
+// are logged.
+func (p *outboundPublisher) PublishEvent(raw json.RawMessage) error {
+	var eventHeader struct {
+		Event   EventName    `json:"event"`
+		Context EventContext `json:"context"`
+		Payload struct {
+			Target streamdeckevent.Target `json:"target"`
+			State  *int                   `json:"state"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(raw, &eventHeader); err != nil {
+		return fmt.Errorf("unmarshalling event header: %w", err)
+	}
+
+	key := outboundKey{eventName: eventHeader.Event, eventContext: eventHeader.Context, state: allStates}
+	switch eventHeader.Event {
+	case streamdeckevent.SetTitleName, streamdeckevent.SetImageName:
+		key.target = eventHeader.Payload.Target
+		if eventHeader.Payload.State != nil {
+			key.state = *eventHeader.Payload.State
+		}
+	case streamdeckevent.SetStateName:
+		// The state is the value being set, so every SetState for the context supersedes the last.
+	default:
+		return p.publisher.PublishEvent(raw)
+	}
+
+	p.mu.Lock()
+	if p.delivered[key] == string(raw) {
+		// An event waiting to be published is superseded by one restoring the delivered value.
+		if _, ok := p.pending[key]; ok {
+			delete(p.pending, key)
+			p.removeFromOrder(key)
+		}
+
+		p.mu.Unlock()
+		return nil
+	}
+
+	if _, ok := p.pending[key]; !ok && !time.Now().Before(p.next) {
+		p.delivered[key] = string(raw)
+		p.next = time.Now().Add(p.interval)
+		p.delivering.Lock()
+		p.mu.Unlock()
+
+		return p.deliver(key, raw)
+	}
+
+	if _, ok := p.pending[key]; !ok {
+		p.order = append(p.order, key)
+	}
+	p.pending[key] = raw
+
+	if !p.flushing {
+		p.flushing = true
+		go p.flush()
+	}
+	p.mu.Unlock()
+
+	return nil
+}
+
+// forget clears what has been delivered for the context, for instance because the application has reset the key.
+func (p *outboundPublisher) forget(eventContext EventContext) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key := range p.delivered {
+		if key.eventContext == eventContext {
+			delete(p.delivered, key)
+		}
+	}
+}
+
+// forgetState clears the state delivered for the context, since the application changes the state of a key with
+// multiple states itself when it is pressed.
+func (p *outboundPublisher) forgetState(eventContext EventContext) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.delivered, outboundKey{eventName: streamdeckevent.SetStateName, eventContext: eventContext, state: allStates})
+}
+
+// reset clears what has been delivered and discards the pending events, for instance because the connection has
+// been lost.
+func (p *outboundPublisher) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.delivered = make(map[outboundKey]string)
+	p.pending = make(map[outboundKey]json.RawMessage)
+	p.order = nil
+}
+
+// flush publishes the pending events in the order they were first queued, as the rate allows, until none remain.
+func (p *outboundPublisher) flush() {
+	for {
+		p.mu.Lock()
+		if len(p.order) == 0 {
+			p.flushing = false
+			p.mu.Unlock()
+			return
+		}
+
+		if wait := time.Until(p.next); wait > 0 {
+			p.mu.Unlock()
+			time.Sleep(wait)
+			continue
+		}
+
+		key := p.order[0]
+		p.order = p.order[1:]
+		raw := p.pending[key]
+		delete(p.pending, key)
+		p.delivered[key] = string(raw)
+		p.next = time.Now().Add(p.interval)
+		p.delivering.Lock()
+		p.mu.Unlock()
+
+		if err := p.deliver(key, raw); err != nil {
+			p.logger.Error("publishing coalesced event",
+				slog.String("event", string(key.eventName)),
+				slog.String("context", string(key.eventContext)),
+				slog.Any("error", err))
+		}
+	}
+}
+
+// deliver publishes the event, forgetting it was delivered when publishing fails so that it may be retried. It must be
+// called holding delivering, which it unlocks.
+func (p *outboundPublisher) deliver(key outboundKey, raw json.RawMessage) error {
+	err := p.publisher.PublishEvent(raw)
+	p.delivering.Unlock()
+
+	if err != nil {
+		p.mu.Lock()
+		if p.delivered[key] == string(raw) {
+			delete(p.delivered, key)
+		}
+		p.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+func (p *outboundPublisher) removeFromOrder(key outboundKey) {
+	for i, existing := range p.order {
+		if existing == key {
+			p.order = append(p.order[:i], p.order[i+1:]...)
+			return
+		}
+	}
+}
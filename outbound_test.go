@@ -0,0 +1,193 @@
+package streamdeck_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+// titleInstance sets the titles it is given whenever its key is pressed.
+type titleInstance struct {
+	eventContext streamdeck.EventContext
+	publisher    streamdeck.ActionInstancePublisher
+	titles       []streamdeckevent.SetTitlePayload
+}
+
+func (i *titleInstance) ActionUUID() streamdeck.ActionUUID {
+	return testActionUUID
+}
+
+func (i *titleInstance) EventContext() streamdeck.EventContext {
+	return i.eventContext
+}
+
+func (i *titleInstance) HandleKeyDown(_ context.Context, _ streamdeckevent.KeyDown) error {
+	for _, title := range i.titles {
+		if err := i.publisher.SetTitle(title); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func serveTitles(t *testing.T, opts streamdeck.OutboundOptions, titles ...streamdeckevent.SetTitlePayload) *streamdecktest.Host {
+	t.Helper()
+
+	action := streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		return &titleInstance{eventContext: eventContext, publisher: publisher, titles: titles}
+	})
+	host, _ := servePlugin(t, streamdeck.NewPluginWithOptions(streamdeck.PluginOptions{Outbound: &opts}, action))
+
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 0))
+
+	return host
+}
+
+func waitForTitles(t *testing.T, host *streamdecktest.Host, want ...streamdeckevent.SetTitlePayload) {
+	t.Helper()
+
+	var got []streamdeckevent.SetTitlePayload
+	waitUntil(t, func() bool {
+		got = got[:0]
+		for _, event := range host.Titles("key") {
+			got = append(got, event.Payload)
+		}
+		return len(got) >= len(want)
+	})
+
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Fatalf("expected titles %s, got %s", wantJSON, gotJSON)
+	}
+}
+
+func state(state int) *int {
+	return &state
+}
+
+func TestOutboundWithoutRateLimitPublishesEveryEvent(t *testing.T) {
+	var titles []streamdeckevent.SetTitlePayload
+	for i := 0; i < 20; i++ {
+		titles = append(titles, streamdeckevent.SetTitlePayload{Title: fmt.Sprint(i)})
+	}
+
+	host := serveTitles(t, streamdeck.OutboundOptions{}, titles...)
+
+	waitForTitles(t, host, titles...)
+}
+
+func TestOutboundCoalescesOnlyMatchingStateAndTarget(t *testing.T) {
+	host := serveTitles(t, streamdeck.OutboundOptions{MaxPerSecond: 20},
+		streamdeckevent.SetTitlePayload{Title: "first", State: state(0)},
+		streamdeckevent.SetTitlePayload{Title: "zero", State: state(0)},
+		streamdeckevent.SetTitlePayload{Title: "one", State: state(1)},
+		streamdeckevent.SetTitlePayload{Title: "software", State: state(0), Target: streamdeckevent.OnlySoftware},
+		streamdeckevent.SetTitlePayload{Title: "all"},
+		streamdeckevent.SetTitlePayload{Title: "latest zero", State: state(0)},
+	)
+
+	// The first title goes out immediately. Of the rest, only the second title for state 0 on both targets is
+	// superseded while waiting for its turn.
+	waitForTitles(t, host,
+		streamdeckevent.SetTitlePayload{Title: "first", State: state(0)},
+		streamdeckevent.SetTitlePayload{Title: "latest zero", State: state(0)},
+		streamdeckevent.SetTitlePayload{Title: "one", State: state(1)},
+		streamdeckevent.SetTitlePayload{Title: "software", State: state(0), Target: streamdeckevent.OnlySoftware},
+		streamdeckevent.SetTitlePayload{Title: "all"},
+	)
+}
+
+// stateInstance sets its key to state 1 whenever it is pressed.
+type stateInstance struct {
+	eventContext streamdeck.EventContext
+	publisher    streamdeck.ActionInstancePublisher
+}
+
+func (i *stateInstance) ActionUUID() streamdeck.ActionUUID {
+	return testActionUUID
+}
+
+func (i *stateInstance) EventContext() streamdeck.EventContext {
+	return i.eventContext
+}
+
+func (i *stateInstance) HandleKeyDown(_ context.Context, _ streamdeckevent.KeyDown) error {
+	return i.publisher.SetState(streamdeckevent.SetStatePayload{State: 1})
+}
+
+func TestOutboundResendsStateAfterKeyPress(t *testing.T) {
+	action := streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		return &stateInstance{eventContext: eventContext, publisher: publisher}
+	})
+	host, _ := servePlugin(t, streamdeck.NewPluginWithOptions(streamdeck.PluginOptions{Outbound: &streamdeck.OutboundOptions{}}, action))
+
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 0))
+	waitUntil(t, func() bool {
+		return len(host.ReceivedEvents(streamdeckevent.SetStateName, "key")) == 1
+	})
+
+	// Releasing the key toggles it back to state 0, so setting state 1 again is not redundant.
+	mustSend(t, host, keyUp("key", 0))
+	mustSend(t, host, keyDown("key", 0))
+	waitUntil(t, func() bool {
+		return len(host.ReceivedEvents(streamdeckevent.SetStateName, "key")) == 2
+	})
+}
+
+func TestOutboundDeliversInOrderOfRecord(t *testing.T) {
+	publishers := make(chan streamdeck.ActionInstancePublisher, 1)
+	action := streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		publishers <- publisher
+		return &titleInstance{eventContext: eventContext, publisher: publisher}
+	})
+	host, _ := servePlugin(t, streamdeck.NewPluginWithOptions(streamdeck.PluginOptions{Outbound: &streamdeck.OutboundOptions{}}, action))
+
+	mustSend(t, host, willAppear("key"))
+	publisher := <-publishers
+
+	// Publishers racing for the same key must leave the key showing what was last recorded as delivered.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = publisher.SetTitle(streamdeckevent.SetTitlePayload{Title: fmt.Sprint((i + j) % 2)})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	titles := func() []streamdeckevent.SetTitle {
+		return host.Titles("key")
+	}
+	var shown string
+	waitUntil(t, func() bool {
+		before := len(titles())
+		time.Sleep(20 * time.Millisecond)
+		latest := titles()
+		if len(latest) == 0 || len(latest) != before {
+			return false
+		}
+		shown = latest[len(latest)-1].Payload.Title
+		return true
+	})
+
+	// Were the record out of step with the key, the other title would be skipped as redundant.
+	other := map[string]string{"0": "1", "1": "0"}[shown]
+	if err := publisher.SetTitle(streamdeckevent.SetTitlePayload{Title: other}); err != nil {
+		t.Fatalf("setting title: %v", err)
+	}
+	host.AssertTitle(t, "key", other)
+}
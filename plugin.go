@@ -3,65 +3,293 @@ package streamdeck
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
 )
 
-// NewPlugin makes a Plugin.
+// PluginOptions configures a Plugin.
+type PluginOptions struct {
+	// MaxFrameRate is the number of animation frames per second published across all keys. A MaxFrameRate of 0 uses
+	// DefaultMaxFrameRate.
+	MaxFrameRate int
+	// Outbound, when set, skips redundant updates to the faces of keys and rate-limits the rest.
+	Outbound *OutboundOptions
+	// Logger receives the logs of the Plugin and of those of its actions without a logger of their own, such as an
+	// InstancedAction made without WithLogger. A nil Logger uses slog.Default().
+	Logger *slog.Logger
+}
+
+// NewPlugin makes a Plugin with the default options.
 func NewPlugin(actions ...Action) *Plugin {
+	return NewPluginWithOptions(PluginOptions{}, actions...)
+}
+
+// NewPluginWithOptions makes a Plugin configured with the options.
+func NewPluginWithOptions(opts PluginOptions, actions ...Action) *Plugin {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	actionMap := make(map[ActionUUID]Action, len(actions))
 	for _, action := range actions {
 		actionMap[action.ActionUUID()] = action
+		if l, ok := action.(loggerInheritor); ok {
+			l.inheritLogger(logger)
+		}
 	}
 
-	return &Plugin{
-		actions: actionMap,
+	p := &Plugin{
+		actions:  actionMap,
+		ordered:  append([]Action(nil), actions...),
+		replies:  newReplyRouter(),
+		animator: newAnimator(opts.MaxFrameRate, logger),
 	}
+
+	if opts.Outbound != nil {
+		p.outbound = newOutboundPublisher(*opts.Outbound, logger)
+	}
+
+	return p
+}
+
+// loggerInheritor is implemented by actions that log using the Plugin's logger unless configured otherwise.
+type loggerInheritor interface {
+	inheritLogger(logger *slog.Logger)
 }
 
 // Plugin is the default implementation of a streamdeckcore.Plugin. It handles the raw events
 // and dispatches them to the appropriate actions.
+//
+// A Plugin is safe for concurrent use. Its actions are fixed when it is made, so the lookup methods may be called from
+// any goroutine, such as one polling for updates in the background.
 type Plugin struct {
-	actions map[ActionUUID]Action
+	actions  map[ActionUUID]Action
+	ordered  []Action
+	attached []streamdeckcore.Plugin
+	replies  *replyRouter
+	animator *animator
+	outbound *outboundPublisher
+
+	mu   sync.RWMutex
+	info RegistrationInfo
+}
+
+// Attach adds plugin-level components, such as a GlobalSettings, that receive every event ahead of the actions. The
+// optional interfaces of streamdeckcore, such as streamdeckcore.ConnectedHandler, are forwarded to them as well.
+// Attach must be called before the Plugin is served.
+func (p *Plugin) Attach(components ...streamdeckcore.Plugin) {
+	p.attached = append(p.attached, components...)
 }
 
 // Initialize implements the streamdeckcore.Plugin interface.
-func (p *Plugin) Initialize(pluginUUID PluginUUID, publisher Publisher) {
+func (p *Plugin) Initialize(pluginUUID PluginUUID, info RegistrationInfo, publisher Publisher) {
+	p.mu.Lock()
+	p.info = info
+	p.mu.Unlock()
+
+	if p.outbound != nil {
+		p.outbound.publisher = publisher
+		publisher = p.outbound
+	}
+
+	for _, component := range p.attached {
+		component.Initialize(pluginUUID, info, publisher)
+	}
+
 	for _, action := range p.actions {
-		ap := newCoreActionPublisher(pluginUUID, action.ActionUUID(), publisher)
-		action.InitializeAction(pluginUUID, ap)
+		ap := newCoreActionPublisher(pluginUUID, action.ActionUUID(), publisher, p.replies, p.animator)
+		action.InitializeAction(pluginUUID, info, ap)
 	}
 }
 
 // HandleEvent implements the streamdeckcore.Handler interface.
 func (p *Plugin) HandleEvent(ctx context.Context, raw json.RawMessage) error {
 	var eventHeader struct {
-		Event  EventName  `json:"event"`
-		Action ActionUUID `json:"action"`
+		Event   EventName    `json:"event"`
+		Action  ActionUUID   `json:"action"`
+		Context EventContext `json:"context"`
 	}
 	if err := json.Unmarshal(raw, &eventHeader); err != nil {
 		return fmt.Errorf("unmarshalling event header: %w", err)
 	}
 
+	// Replies are handed to those fetching them and then dispatched like any other event.
+	switch eventHeader.Event {
+	case streamdeckevent.DidReceiveSettingsName, streamdeckevent.DidReceiveGlobalSettingsName:
+		p.replies.deliver(eventHeader.Event, eventHeader.Context, raw)
+	case streamdeckevent.WillAppearName, streamdeckevent.WillDisappearName:
+		// The application resets a key as it appears, so whatever was delivered to it before no longer shows.
+		if p.outbound != nil {
+			p.outbound.forget(eventHeader.Context)
+		}
+	case streamdeckevent.KeyDownName, streamdeckevent.KeyUpName:
+		// The application toggles the state of a key with multiple states as it is pressed.
+		if p.outbound != nil {
+			p.outbound.forgetState(eventHeader.Context)
+		}
+	}
+
+	// A component or action failing to handle the event doesn't keep it from the others, so the errors are collected.
+	var errs []error
+	for _, component := range p.attached {
+		if err := component.HandleEvent(ctx, raw); err != nil {
+			errs = append(errs, fmt.Errorf("dispatching event %q to attached component: %w", eventHeader.Event, err))
+		}
+	}
+
 	// If the action is empty, the event is intended for all actions.
 	if eventHeader.Action == "" {
 		for _, action := range p.actions {
 			if err := dispatchEvent(ctx, action, eventHeader.Event, raw); err != nil {
-				return fmt.Errorf("dispatching event %q to action %q: %w", eventHeader.Event, eventHeader.Action, err)
+				errs = append(errs, fmt.Errorf("dispatching event %q to action %q: %w", eventHeader.Event, action.ActionUUID(), err))
 			}
 		}
 
-		return nil
+		return errors.Join(errs...)
 	}
 
 	// If the action doesn't exist, it wasn't registered and this plugin should not have received this event.
-	action, ok := p.actions[eventHeader.Action]
-	if !ok {
-		return fmt.Errorf("unknown action %q", eventHeader.Action)
+	if action, ok := p.actions[eventHeader.Action]; !ok {
+		errs = append(errs, fmt.Errorf("unknown action %q", eventHeader.Action))
+	} else if err := dispatchEvent(ctx, action, eventHeader.Event, raw); err != nil {
+		errs = append(errs, fmt.Errorf("dispatching event %q to action %q: %w", eventHeader.Event, eventHeader.Action, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// HandleConnected implements the streamdeckcore.ConnectedHandler interface. Like the other connection handlers, it
+// notifies every attached component and action however many fail, returning their errors joined.
+func (p *Plugin) HandleConnected(ctx context.Context) error {
+	var errs []error
+	for _, component := range p.attached {
+		if h, ok := component.(ConnectedHandler); ok {
+			if err := h.HandleConnected(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("handling connect for attached component: %w", err))
+			}
+		}
 	}
 
-	if err := dispatchEvent(ctx, action, eventHeader.Event, raw); err != nil {
-		return fmt.Errorf("dispatching event %q to action %q: %w", eventHeader.Event, eventHeader.Action, err)
+	for _, action := range p.actions {
+		if h, ok := action.(ConnectedHandler); ok {
+			if err := h.HandleConnected(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("handling connect for action %q: %w", action.ActionUUID(), err))
+			}
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// HandleDisconnected implements the streamdeckcore.DisconnectedHandler interface. Running animations and pending
+// outbound events are discarded, as they can no longer be published.
+func (p *Plugin) HandleDisconnected(ctx context.Context, err error) error {
+	p.animator.cancelAll()
+	if p.outbound != nil {
+		p.outbound.reset()
+	}
+
+	var errs []error
+	for _, component := range p.attached {
+		if h, ok := component.(DisconnectedHandler); ok {
+			if herr := h.HandleDisconnected(ctx, err); herr != nil {
+				errs = append(errs, fmt.Errorf("handling disconnect for attached component: %w", herr))
+			}
+		}
+	}
+
+	for _, action := range p.actions {
+		if h, ok := action.(DisconnectedHandler); ok {
+			if herr := h.HandleDisconnected(ctx, err); herr != nil {
+				errs = append(errs, fmt.Errorf("handling disconnect for action %q: %w", action.ActionUUID(), herr))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// HandleReconnected implements the streamdeckcore.ReconnectedHandler interface.
+func (p *Plugin) HandleReconnected(ctx context.Context) error {
+	var errs []error
+	for _, component := range p.attached {
+		if h, ok := component.(ReconnectedHandler); ok {
+			if err := h.HandleReconnected(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("handling reconnect for attached component: %w", err))
+			}
+		}
+	}
+
+	for _, action := range p.actions {
+		if h, ok := action.(ReconnectedHandler); ok {
+			if err := h.HandleReconnected(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("handling reconnect for action %q: %w", action.ActionUUID(), err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Info returns the RegistrationInfo the Plugin was initialized with.
+func (p *Plugin) Info() RegistrationInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.info
+}
+
+// Action returns the registered action with the UUID, if one exists.
+func (p *Plugin) Action(actionUUID ActionUUID) (Action, bool) {
+	action, ok := p.actions[actionUUID]
+	return action, ok
+}
+
+// Instance returns the live action instance for the context, searching every action implementing the
+// InstancedActionProvider interface.
+func (p *Plugin) Instance(eventContext EventContext) (ActionInstance, bool) {
+	for _, action := range p.actions {
+		if provider, ok := action.(InstancedActionProvider); ok {
+			if instance, ok := provider.Instance(eventContext); ok {
+				return instance, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Instances returns a snapshot of the live action instances of every action implementing the
+// InstancedActionProvider interface.
+func (p *Plugin) Instances() []ActionInstance {
+	var instances []ActionInstance
+	for _, action := range p.actions {
+		if provider, ok := action.(InstancedActionProvider); ok {
+			instances = append(instances, provider.Instances()...)
+		}
+	}
+
+	return instances
+}
+
+// Drain implements the streamdeckcore.Drainer interface. Running animations are stopped.
+func (p *Plugin) Drain() {
+	p.animator.cancelAll()
+
+	for _, component := range p.attached {
+		if d, ok := component.(streamdeckcore.Drainer); ok {
+			d.Drain()
+		}
+	}
+
+	for _, action := range p.actions {
+		if d, ok := action.(streamdeckcore.Drainer); ok {
+			d.Drain()
+		}
+	}
 }
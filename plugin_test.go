@@ -0,0 +1,187 @@
+package streamdeck_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+// fetchingAction fetches the global settings as soon as the plugin has connected.
+type fetchingAction struct {
+	publisher streamdeck.ActionPublisher
+	fetched   chan json.RawMessage
+}
+
+func (a *fetchingAction) ActionUUID() streamdeck.ActionUUID {
+	return testActionUUID
+}
+
+func (a *fetchingAction) InitializeAction(_ streamdeck.PluginUUID, _ streamdeck.RegistrationInfo, publisher streamdeck.ActionPublisher) {
+	a.publisher = publisher
+}
+
+func (a *fetchingAction) HandleConnected(ctx context.Context) error {
+	settings, err := a.publisher.FetchGlobalSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.fetched <- settings
+	return nil
+}
+
+func TestPluginConnectedHandlerFetchesGlobalSettings(t *testing.T) {
+	action := &fetchingAction{fetched: make(chan json.RawMessage, 1)}
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := host.WaitForEvent(ctx, streamdeckevent.GetGlobalSettingsName, streamdeck.EventContext(streamdecktest.PluginUUID)); err != nil {
+		t.Fatalf("waiting for the request: %v", err)
+	}
+	mustSend(t, host, streamdecktest.NewDidReceiveGlobalSettings(json.RawMessage(`{"theme":"dark"}`)))
+
+	select {
+	case settings := <-action.fetched:
+		if string(settings) != `{"theme":"dark"}` {
+			t.Fatalf("expected the global settings, got %s", settings)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the connected handler to fetch the global settings")
+	}
+}
+
+// failingComponent fails to handle every event.
+type failingComponent struct{}
+
+func (failingComponent) Initialize(streamdeck.PluginUUID, streamdeck.RegistrationInfo, streamdeck.Publisher) {
+}
+
+func (failingComponent) HandleEvent(context.Context, json.RawMessage) error {
+	return errors.New("broken")
+}
+
+func (failingComponent) HandleConnected(context.Context) error {
+	return errors.New("broken")
+}
+
+func (failingComponent) HandleDisconnected(context.Context, error) error {
+	return errors.New("broken")
+}
+
+func (failingComponent) HandleReconnected(context.Context) error {
+	return errors.New("broken")
+}
+
+// failingInstance fails to handle the connection changing and the global settings, counting its attempts.
+type failingInstance struct {
+	eventContext streamdeck.EventContext
+	calls        *atomic.Int32
+}
+
+func (i *failingInstance) ActionUUID() streamdeck.ActionUUID {
+	return testActionUUID
+}
+
+func (i *failingInstance) EventContext() streamdeck.EventContext {
+	return i.eventContext
+}
+
+func (i *failingInstance) HandleDisconnected(context.Context, error) error {
+	i.calls.Add(1)
+	return errors.New("broken")
+}
+
+func (i *failingInstance) HandleReconnected(context.Context) error {
+	i.calls.Add(1)
+	return errors.New("broken")
+}
+
+func (i *failingInstance) HandleDidReceiveGlobalSettings(context.Context, streamdeckevent.DidReceiveGlobalSettings) error {
+	i.calls.Add(1)
+	return errors.New("broken")
+}
+
+func TestPluginDispatchesToActionsWhenComponentFails(t *testing.T) {
+	log := newEventLog()
+	plugin := streamdeck.NewPlugin(newRecordingAction(log, nil))
+	plugin.Attach(failingComponent{}, failingComponent{})
+	plugin.Initialize(streamdecktest.PluginUUID, streamdeck.RegistrationInfo{}, streamdecktest.NewRecorder())
+
+	ctx := context.Background()
+	_ = plugin.HandleEvent(ctx, streamdecktest.NewWillAppear(testActionUUID, "key", streamdeckevent.WillAppearPayload{}))
+	err := plugin.HandleEvent(ctx, streamdecktest.NewKeyDown(testActionUUID, "key", streamdeckevent.KeyDownPayload{State: 1}))
+
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) || len(joined.Unwrap()) != 2 {
+		t.Fatalf("expected the errors of both components, got %v", err)
+	}
+	assertLog(t, log.snapshot(), "key#1:willAppear", "key#1:keyDown:1")
+}
+
+func TestPluginCollectsHandlerErrors(t *testing.T) {
+	var calls atomic.Int32
+	action := streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, _ streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		return &failingInstance{eventContext: eventContext, calls: &calls}
+	})
+	plugin := streamdeck.NewPlugin(action)
+	plugin.Attach(failingComponent{}, failingComponent{})
+	plugin.Initialize(streamdecktest.PluginUUID, streamdeck.RegistrationInfo{}, streamdecktest.NewRecorder())
+
+	ctx := context.Background()
+	_ = plugin.HandleEvent(ctx, streamdecktest.NewWillAppear(testActionUUID, "a", streamdeckevent.WillAppearPayload{}))
+	_ = plugin.HandleEvent(ctx, streamdecktest.NewWillAppear(testActionUUID, "b", streamdeckevent.WillAppearPayload{}))
+
+	// Every component and instance is handed the change however many fail before it.
+	testCases := []struct {
+		name   string
+		handle func() error
+		errs   int
+	}{
+		{
+			name:   "connected",
+			handle: func() error { return plugin.HandleConnected(ctx) },
+			errs:   2,
+		},
+		{
+			name:   "disconnected",
+			handle: func() error { return plugin.HandleDisconnected(ctx, errors.New("connection lost")) },
+			errs:   4,
+		},
+		{
+			name:   "reconnected",
+			handle: func() error { return plugin.HandleReconnected(ctx) },
+			errs:   4,
+		},
+		{
+			name: "event for every instance",
+			handle: func() error {
+				return plugin.HandleEvent(ctx, streamdecktest.NewDidReceiveGlobalSettings(json.RawMessage(`{}`)))
+			},
+			errs: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls.Store(0)
+
+			err := tc.handle()
+			if got := strings.Count(fmt.Sprint(err), "broken"); got != tc.errs {
+				t.Fatalf("expected %d errors, got %v", tc.errs, err)
+			}
+			if got := calls.Load(); int(got) != tc.errs-2 {
+				t.Fatalf("expected every instance to be called, got %d calls", got)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package streamdeck
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 
 	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
 	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
@@ -16,34 +18,60 @@ var (
 // Publisher publishes events for a plugin. It is an alias for a Publisher.
 type Publisher = streamdeckcore.Publisher
 
-// ActionPublisher publishes events for an Action, filling in details specific to the Action.
+// ActionPublisher publishes events for an Action, filling in details specific to the Action. It is safe for concurrent
+// use.
+//
+// The Fetch methods send a request and wait for the device's reply, which is still delivered to the regular handlers.
+// As replies are read by the goroutine dispatching events, they must not be called from a handler unless the action
+// handles events asynchronously, as an InstancedAction made with WithQueue does.
+//
+// Animate shows the frames of an Animation on a key in the background, replacing any animation already running for
+// the context, until it ends or StopAnimation is called. The frames of every animated key share the frame budget set
+// by PluginOptions.MaxFrameRate, skipping frames rather than flooding the device when the budget is exhausted.
 type ActionPublisher interface {
 	Publisher
 
+	Animate(eventContext EventContext, animation Animation) error
+	FetchGlobalSettings(ctx context.Context) (json.RawMessage, error)
+	FetchSettings(ctx context.Context, eventContext EventContext) (json.RawMessage, error)
 	GetGlobalSettings() error
 	GetSettings(eventContext EventContext) error
 	LogMessage(payload streamdeckevent.LogMessagePayload) error
 	OpenURL(payload streamdeckevent.OpenURLPayload) error
 	SendToPropertyInspector(eventContext EventContext, payload json.RawMessage) error
+	SetFeedback(eventContext EventContext, payload streamdeckevent.SetFeedbackPayload) error
+	SetFeedbackLayout(eventContext EventContext, payload streamdeckevent.SetFeedbackLayoutPayload) error
 	SetGlobalSettings(settings json.RawMessage) error
 	SetImage(eventContext EventContext, payload streamdeckevent.SetImagePayload) error
 	SetSettings(eventContext EventContext, settings json.RawMessage) error
 	SetState(eventContext EventContext, payload streamdeckevent.SetStatePayload) error
 	SetTitle(eventContext EventContext, payload streamdeckevent.SetTitlePayload) error
+	SetTriggerDescription(eventContext EventContext, payload streamdeckevent.SetTriggerDescriptionPayload) error
 	ShowAlert(eventContext EventContext) error
 	ShowOK(eventContext EventContext) error
+	StopAnimation(eventContext EventContext)
 	SwitchToProfile(eventContext EventContext, payload streamdeckevent.SwitchToProfilePayload) error
 }
 
+// NewActionPublisher makes an ActionPublisher that publishes events for the action through the provided Publisher.
+// No replies are routed to it, so its Fetch methods fail, and its animations are scheduled with DefaultMaxFrameRate.
+func NewActionPublisher(pluginUUID PluginUUID, actionUUID ActionUUID, publisher Publisher) ActionPublisher {
+	return newCoreActionPublisher(pluginUUID, actionUUID, publisher, nil, newAnimator(DefaultMaxFrameRate, slog.Default()))
+}
+
 func newCoreActionPublisher(
 	pluginUUID PluginUUID,
 	actionUUID ActionUUID,
-	corePublisher Publisher) *coreActionPublisher {
+	corePublisher Publisher,
+	replies *replyRouter,
+	animator *animator) *coreActionPublisher {
 
 	return &coreActionPublisher{
 		pluginUUID:    pluginUUID,
 		actionUUID:    actionUUID,
 		corePublisher: corePublisher,
+		replies:       replies,
+		animator:      animator,
 	}
 }
 
@@ -52,6 +80,54 @@ type coreActionPublisher struct {
 	pluginUUID    PluginUUID
 	actionUUID    ActionUUID
 	corePublisher Publisher
+	replies       *replyRouter
+	animator      *animator
+}
+
+func (p *coreActionPublisher) Animate(eventContext EventContext, animation Animation) error {
+	return p.animate(eventContext, animation, func(payload streamdeckevent.SetImagePayload) error {
+		return p.SetImage(eventContext, payload)
+	})
+}
+
+// animate runs the animation, publishing each frame with publish.
+func (p *coreActionPublisher) animate(eventContext EventContext, animation Animation, publish func(streamdeckevent.SetImagePayload) error) error {
+	if p.animator == nil {
+		return errAnimationsNotSupported
+	}
+
+	p.animator.start(eventContext, animation, publish)
+	return nil
+}
+
+func (p *coreActionPublisher) FetchGlobalSettings(ctx context.Context) (json.RawMessage, error) {
+	raw, err := p.fetch(ctx, streamdeckevent.DidReceiveGlobalSettingsName, "", p.GetGlobalSettings)
+	if err != nil {
+		return nil, fmt.Errorf("fetching global settings: %w", err)
+	}
+
+	var event streamdeckevent.DidReceiveGlobalSettings
+	if err = json.Unmarshal(raw, &event); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DidReceiveGlobalSettingsName, err)
+	}
+
+	return event.Payload.Settings, nil
+}
+
+func (p *coreActionPublisher) FetchSettings(ctx context.Context, eventContext EventContext) (json.RawMessage, error) {
+	raw, err := p.fetch(ctx, streamdeckevent.DidReceiveSettingsName, eventContext, func() error {
+		return p.GetSettings(eventContext)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching settings: %w", err)
+	}
+
+	var event streamdeckevent.DidReceiveSettings
+	if err = json.Unmarshal(raw, &event); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %w", streamdeckevent.DidReceiveSettingsName, err)
+	}
+
+	return event.Payload.Settings, nil
 }
 
 func (p *coreActionPublisher) GetGlobalSettings() error {
@@ -101,6 +177,26 @@ func (p *coreActionPublisher) SendToPropertyInspector(eventContext EventContext,
 	return p.publish(event.Event, event)
 }
 
+func (p *coreActionPublisher) SetFeedback(eventContext EventContext, payload streamdeckevent.SetFeedbackPayload) error {
+	event := streamdeckevent.SetFeedback{
+		Event:   streamdeckevent.SetFeedbackName,
+		Context: eventContext,
+		Payload: payload,
+	}
+
+	return p.publish(event.Event, event)
+}
+
+func (p *coreActionPublisher) SetFeedbackLayout(eventContext EventContext, payload streamdeckevent.SetFeedbackLayoutPayload) error {
+	event := streamdeckevent.SetFeedbackLayout{
+		Event:   streamdeckevent.SetFeedbackLayoutName,
+		Context: eventContext,
+		Payload: payload,
+	}
+
+	return p.publish(event.Event, event)
+}
+
 func (p *coreActionPublisher) SetGlobalSettings(settings json.RawMessage) error {
 	event := streamdeckevent.SetGlobalSettings{
 		Event:   streamdeckevent.SetGlobalSettingsName,
@@ -151,6 +247,16 @@ func (p *coreActionPublisher) SetTitle(eventContext EventContext, payload stream
 	return p.publish(event.Event, event)
 }
 
+func (p *coreActionPublisher) SetTriggerDescription(eventContext EventContext, payload streamdeckevent.SetTriggerDescriptionPayload) error {
+	event := streamdeckevent.SetTriggerDescription{
+		Event:   streamdeckevent.SetTriggerDescriptionName,
+		Context: eventContext,
+		Payload: payload,
+	}
+
+	return p.publish(event.Event, event)
+}
+
 func (p *coreActionPublisher) ShowAlert(eventContext EventContext) error {
 	event := streamdeckevent.ShowAlert{
 		Event:   streamdeckevent.ShowAlertName,
@@ -169,6 +275,12 @@ func (p *coreActionPublisher) ShowOK(eventContext EventContext) error {
 	return p.publish(event.Event, event)
 }
 
+func (p *coreActionPublisher) StopAnimation(eventContext EventContext) {
+	if p.animator != nil {
+		p.animator.cancel(eventContext)
+	}
+}
+
 func (p *coreActionPublisher) SwitchToProfile(eventContext EventContext, payload streamdeckevent.SwitchToProfilePayload) error {
 	event := streamdeckevent.SwitchToProfile{
 		Event:   streamdeckevent.SwitchToProfileName,
@@ -184,6 +296,14 @@ func (p *coreActionPublisher) PublishEvent(raw json.RawMessage) error {
 	return p.corePublisher.PublishEvent(raw)
 }
 
+func (p *coreActionPublisher) fetch(ctx context.Context, replyName EventName, eventContext EventContext, send func() error) (json.RawMessage, error) {
+	if p.replies == nil {
+		return nil, errRepliesNotRouted
+	}
+
+	return p.replies.await(ctx, replyName, eventContext, send)
+}
+
 func (p *coreActionPublisher) publish(eventName EventName, event interface{}) error {
 	raw, err := json.Marshal(event)
 	if err != nil {
@@ -197,25 +317,42 @@ func (p *coreActionPublisher) publish(eventName EventName, event interface{}) er
 	return nil
 }
 
-// ActionInstancePublisher publishes events for an ActionInstance, filling in details specific to the ActionInstance.
+// ActionInstancePublisher publishes events for an ActionInstance, filling in details specific to the ActionInstance. It
+// is safe for concurrent use, so instances may publish from their own goroutines.
+//
+// The Fetch and Animate methods behave as those of ActionPublisher. An InstancedAction stops the animation of an
+// instance when it disappears.
 type ActionInstancePublisher interface {
 	Publisher
 
+	Animate(animation Animation) error
+	FetchGlobalSettings(ctx context.Context) (json.RawMessage, error)
+	FetchSettings(ctx context.Context) (json.RawMessage, error)
 	GetGlobalSettings() error
 	GetSettings() error
 	LogMessage(payload streamdeckevent.LogMessagePayload) error
 	OpenURL(payload streamdeckevent.OpenURLPayload) error
 	SendToPropertyInspector(payload json.RawMessage) error
+	SetFeedback(payload streamdeckevent.SetFeedbackPayload) error
+	SetFeedbackLayout(payload streamdeckevent.SetFeedbackLayoutPayload) error
 	SetGlobalSettings(settings json.RawMessage) error
 	SetImage(payload streamdeckevent.SetImagePayload) error
 	SetSettings(settings json.RawMessage) error
 	SetState(payload streamdeckevent.SetStatePayload) error
 	SetTitle(payload streamdeckevent.SetTitlePayload) error
+	SetTriggerDescription(payload streamdeckevent.SetTriggerDescriptionPayload) error
 	ShowAlert() error
 	ShowOK() error
+	StopAnimation()
 	SwitchToProfile(payload streamdeckevent.SwitchToProfilePayload) error
 }
 
+// NewActionInstancePublisher makes an ActionInstancePublisher that publishes events for the action instance through
+// the provided ActionPublisher.
+func NewActionInstancePublisher(eventContext EventContext, publisher ActionPublisher) ActionInstancePublisher {
+	return newCoreActionInstancePublisher(eventContext, publisher)
+}
+
 func newCoreActionInstancePublisher(
 	eventContext EventContext,
 	corePublisher ActionPublisher) *coreActionInstancePublisher {
@@ -229,6 +366,25 @@ func newCoreActionInstancePublisher(
 type coreActionInstancePublisher struct {
 	eventContext    EventContext
 	actionPublisher ActionPublisher
+	visuals         *visualCache
+}
+
+// Animate publishes the frames through SetImage when it can, so the frame last shown is the image replayed when the
+// instance appears again.
+func (p *coreActionInstancePublisher) Animate(animation Animation) error {
+	if ap, ok := p.actionPublisher.(*coreActionPublisher); ok {
+		return ap.animate(p.eventContext, animation, p.SetImage)
+	}
+
+	return p.actionPublisher.Animate(p.eventContext, animation)
+}
+
+func (p *coreActionInstancePublisher) FetchGlobalSettings(ctx context.Context) (json.RawMessage, error) {
+	return p.actionPublisher.FetchGlobalSettings(ctx)
+}
+
+func (p *coreActionInstancePublisher) FetchSettings(ctx context.Context) (json.RawMessage, error) {
+	return p.actionPublisher.FetchSettings(ctx, p.eventContext)
 }
 
 func (p *coreActionInstancePublisher) GetGlobalSettings() error {
@@ -251,12 +407,28 @@ func (p *coreActionInstancePublisher) SendToPropertyInspector(payload json.RawMe
 	return p.actionPublisher.SendToPropertyInspector(p.eventContext, payload)
 }
 
+func (p *coreActionInstancePublisher) SetFeedback(payload streamdeckevent.SetFeedbackPayload) error {
+	return p.actionPublisher.SetFeedback(p.eventContext, payload)
+}
+
+func (p *coreActionInstancePublisher) SetFeedbackLayout(payload streamdeckevent.SetFeedbackLayoutPayload) error {
+	return p.actionPublisher.SetFeedbackLayout(p.eventContext, payload)
+}
+
 func (p *coreActionInstancePublisher) SetGlobalSettings(settings json.RawMessage) error {
 	return p.actionPublisher.SetGlobalSettings(settings)
 }
 
 func (p *coreActionInstancePublisher) SetImage(payload streamdeckevent.SetImagePayload) error {
-	return p.actionPublisher.SetImage(p.eventContext, payload)
+	if err := p.actionPublisher.SetImage(p.eventContext, payload); err != nil {
+		return err
+	}
+
+	if p.visuals != nil {
+		p.visuals.setImage(payload)
+	}
+
+	return nil
 }
 
 func (p *coreActionInstancePublisher) SetSettings(settings json.RawMessage) error {
@@ -268,7 +440,19 @@ func (p *coreActionInstancePublisher) SetState(payload streamdeckevent.SetStateP
 }
 
 func (p *coreActionInstancePublisher) SetTitle(payload streamdeckevent.SetTitlePayload) error {
-	return p.actionPublisher.SetTitle(p.eventContext, payload)
+	if err := p.actionPublisher.SetTitle(p.eventContext, payload); err != nil {
+		return err
+	}
+
+	if p.visuals != nil {
+		p.visuals.setTitle(payload)
+	}
+
+	return nil
+}
+
+func (p *coreActionInstancePublisher) SetTriggerDescription(payload streamdeckevent.SetTriggerDescriptionPayload) error {
+	return p.actionPublisher.SetTriggerDescription(p.eventContext, payload)
 }
 
 func (p *coreActionInstancePublisher) ShowAlert() error {
@@ -279,6 +463,10 @@ func (p *coreActionInstancePublisher) ShowOK() error {
 	return p.actionPublisher.ShowOK(p.eventContext)
 }
 
+func (p *coreActionInstancePublisher) StopAnimation() {
+	p.actionPublisher.StopAnimation(p.eventContext)
+}
+
 func (p *coreActionInstancePublisher) SwitchToProfile(payload streamdeckevent.SwitchToProfilePayload) error {
 	return p.actionPublisher.SwitchToProfile(p.eventContext, payload)
 }
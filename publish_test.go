@@ -0,0 +1,75 @@
+package streamdeck_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// rawPublisher keeps the events published through it as sent over the wire.
+type rawPublisher struct {
+	events []string
+}
+
+func (p *rawPublisher) PublishEvent(raw json.RawMessage) error {
+	p.events = append(p.events, string(raw))
+	return nil
+}
+
+func TestActionInstancePublisherEncoderEvents(t *testing.T) {
+	testCases := []struct {
+		name    string
+		publish func(publisher streamdeck.ActionInstancePublisher) error
+		want    string
+	}{
+		{
+			name: "setFeedback",
+			publish: func(publisher streamdeck.ActionInstancePublisher) error {
+				return publisher.SetFeedback(streamdeckevent.B1Feedback{
+					Title:     &streamdeckevent.FeedbackText{Value: "Volume"},
+					Value:     &streamdeckevent.FeedbackText{Value: "50%"},
+					Indicator: &streamdeckevent.FeedbackBar{Value: 50},
+				}.Payload())
+			},
+			want: `{"event":"setFeedback","context":"dial","payload":{"title":{"value":"Volume"},"value":{"value":"50%"},"indicator":{"value":50}}}`,
+		},
+		{
+			name: "setFeedbackLayout",
+			publish: func(publisher streamdeck.ActionInstancePublisher) error {
+				return publisher.SetFeedbackLayout(streamdeckevent.SetFeedbackLayoutPayload{Layout: streamdeckevent.LayoutB1})
+			},
+			want: `{"event":"setFeedbackLayout","context":"dial","payload":{"layout":"$B1"}}`,
+		},
+		{
+			name: "setTriggerDescription",
+			publish: func(publisher streamdeck.ActionInstancePublisher) error {
+				return publisher.SetTriggerDescription(streamdeckevent.SetTriggerDescriptionPayload{Push: "Mute", Rotate: "Adjust volume"})
+			},
+			want: `{"event":"setTriggerDescription","context":"dial","payload":{"push":"Mute","rotate":"Adjust volume"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := &rawPublisher{}
+			publisher := streamdeck.NewActionInstancePublisher("dial", streamdeck.NewActionPublisher("plugin", encoderActionUUID, raw))
+
+			if err := tc.publish(publisher); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(raw.events) != 1 {
+				t.Fatalf("expected one event, got %v", raw.events)
+			}
+
+			var got, want interface{}
+			_ = json.Unmarshal([]byte(raw.events[0]), &got)
+			_ = json.Unmarshal([]byte(tc.want), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("expected %s, got %s", tc.want, raw.events[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package streamdeck
+
+import (
+	"context"
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// DefaultQueueSize is the size of an instance's event queue when QueueOptions.Size is not positive.
+const DefaultQueueSize = 64
+
+// OverflowPolicy decides what happens when an event arrives for an instance whose queue is full. The
+// streamdeckevent.WillAppear and streamdeckevent.WillDisappear events are never discarded.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the dispatching goroutine, and therefore every other instance, until the queue has room.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued event.
+	OverflowDropOldest
+	// OverflowCoalesce discards the queued event with the same name as the new event, falling back to
+	// OverflowDropOldest when there is none.
+	OverflowCoalesce
+)
+
+// QueueOptions configures the per-instance event queues of an InstancedAction.
+type QueueOptions struct {
+	// Size is the number of events an instance's queue holds before Overflow applies.
+	Size int
+	// Overflow is the policy applied when an instance's queue is full.
+	Overflow OverflowPolicy
+}
+
+type queuedEvent struct {
+	ctx       context.Context
+	eventName EventName
+	handle    func(context.Context) error
+}
+
+func newEventQueue(opts QueueOptions, pending *pendingEvents) *eventQueue {
+	if opts.Size <= 0 {
+		opts.Size = DefaultQueueSize
+	}
+
+	q := &eventQueue{
+		opts:    opts,
+		pending: pending,
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// eventQueue is a bounded queue of events for a single instance, consumed in order by a single worker.
+type eventQueue struct {
+	opts    QueueOptions
+	pending *pendingEvents
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	events []queuedEvent
+	closed bool
+}
+
+// push adds the event to the queue, applying the overflow policy when the queue is full. Events pushed after the
+// queue has been closed are discarded.
+func (q *eventQueue) push(event queuedEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.opts.Overflow == OverflowBlock {
+		for len(q.events) >= q.opts.Size && !q.closed {
+			q.cond.Wait()
+		}
+	}
+
+	if q.closed {
+		return
+	}
+
+	if len(q.events) >= q.opts.Size && !isLifecycleEvent(event.eventName) {
+		q.discard(event.eventName)
+	}
+
+	q.pending.add()
+	q.events = append(q.events, event)
+	q.cond.Broadcast()
+}
+
+// discard removes an event to make room according to the overflow policy. It must be called while holding the lock.
+func (q *eventQueue) discard(eventName EventName) {
+	idx := -1
+	if q.opts.Overflow == OverflowCoalesce {
+		for i := len(q.events) - 1; i >= 0; i-- {
+			if eventName != "" && q.events[i].eventName == eventName {
+				idx = i
+				break
+			}
+		}
+	}
+
+	if idx == -1 {
+		for i, event := range q.events {
+			if !isLifecycleEvent(event.eventName) {
+				idx = i
+				break
+			}
+		}
+	}
+
+	// When only lifecycle events are queued, the queue temporarily grows past its size.
+	if idx == -1 {
+		return
+	}
+
+	q.events = append(q.events[:idx], q.events[idx+1:]...)
+	q.pending.done()
+}
+
+// pop removes the next event, waiting for one to arrive. It returns false once the queue is closed and empty.
+func (q *eventQueue) pop() (queuedEvent, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.events) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+
+	if len(q.events) == 0 {
+		return queuedEvent{}, false
+	}
+
+	event := q.events[0]
+	q.events[0] = queuedEvent{}
+	q.events = q.events[1:]
+	q.cond.Broadcast()
+	return event, true
+}
+
+// close stops the queue from accepting events. Events already queued are still delivered.
+func (q *eventQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+func isLifecycleEvent(eventName EventName) bool {
+	return eventName == streamdeckevent.WillAppearName || eventName == streamdeckevent.WillDisappearName
+}
+
+func newPendingEvents() *pendingEvents {
+	p := &pendingEvents{}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// pendingEvents counts the events that have been queued but not yet handled, across all of an action's instances.
+type pendingEvents struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	count int
+}
+
+func (p *pendingEvents) add() {
+	p.mu.Lock()
+	p.count++
+	p.mu.Unlock()
+}
+
+func (p *pendingEvents) done() {
+	p.mu.Lock()
+	p.count--
+	if p.count == 0 {
+		p.cond.Broadcast()
+	}
+	p.mu.Unlock()
+}
+
+// wait blocks until there are no pending events.
+func (p *pendingEvents) wait() {
+	p.mu.Lock()
+	for p.count > 0 {
+		p.cond.Wait()
+	}
+	p.mu.Unlock()
+}
@@ -0,0 +1,267 @@
+package streamdeck_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+// holdQueue makes the instance for "key" wait in its streamdeckevent.KeyDown handler, so the events sent afterwards
+// stay in its queue of two until gate is closed.
+func holdQueue(t *testing.T, overflow streamdeck.OverflowPolicy) (*eventLog, *streamdecktest.Host, func()) {
+	t.Helper()
+
+	log := newEventLog()
+	gate := make(chan struct{})
+	action := newRecordingAction(log, gate, streamdeck.WithQueue(streamdeck.QueueOptions{Size: 2, Overflow: overflow}))
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+	release := releaseOnCleanup(t, gate)
+
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, willAppear("probe"))
+	mustSend(t, host, keyDown("key", 0))
+	log.waitFor(t, "key#1:waiting:0", 1)
+
+	return log, host, release
+}
+
+// syncProbe waits until every event sent so far has been pushed to its instance's queue. Events are read one at a
+// time, so once the probe instance has handled its event, the events sent before it have been queued.
+func syncProbe(t *testing.T, log *eventLog, host *streamdecktest.Host, seq int) {
+	t.Helper()
+
+	mustSend(t, host, keyUp("probe", seq))
+	log.waitFor(t, fmt.Sprintf("probe#1:keyUp:%d", seq), 1)
+}
+
+func assertLog(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestInstancedActionOverflowBlock(t *testing.T) {
+	log, host, release := holdQueue(t, streamdeck.OverflowBlock)
+
+	mustSend(t, host, keyDown("key", 1))
+	mustSend(t, host, keyUp("key", 1))
+	mustSend(t, host, keyUp("key", 2))
+	mustSend(t, host, keyUp("probe", 1))
+
+	// The full queue blocks the dispatching goroutine, so the probe instance does not see its event either.
+	time.Sleep(50 * time.Millisecond)
+	if got := log.filter("probe#1:keyUp"); len(got) != 0 {
+		t.Fatalf("expected dispatch to be blocked, got %v", got)
+	}
+
+	release()
+	log.waitFor(t, "probe#1:keyUp:1", 1)
+	assertLog(t, log.waitFor(t, "key#1:", 7),
+		"key#1:willAppear",
+		"key#1:waiting:0",
+		"key#1:keyDown:0",
+		"key#1:waiting:1",
+		"key#1:keyDown:1",
+		"key#1:keyUp:1",
+		"key#1:keyUp:2",
+	)
+}
+
+func TestInstancedActionOverflowDropOldest(t *testing.T) {
+	log, host, release := holdQueue(t, streamdeck.OverflowDropOldest)
+
+	// The streamdeckevent.KeyDown is the oldest event, so it is dropped to make room for the second KeyUp. The
+	// lifecycle event is queued even though the queue is full.
+	mustSend(t, host, keyDown("key", 1))
+	mustSend(t, host, keyUp("key", 1))
+	mustSend(t, host, keyUp("key", 2))
+	mustSend(t, host, willDisappear("key"))
+	syncProbe(t, log, host, 1)
+	release()
+
+	assertLog(t, log.waitFor(t, "key#1:", 7),
+		"key#1:willAppear",
+		"key#1:waiting:0",
+		"key#1:keyDown:0",
+		"key#1:keyUp:1",
+		"key#1:keyUp:2",
+		"key#1:willDisappear",
+		"key#1:dispose",
+	)
+}
+
+func TestInstancedActionOverflowCoalesce(t *testing.T) {
+	log, host, release := holdQueue(t, streamdeck.OverflowCoalesce)
+
+	// The second streamdeckevent.KeyUp replaces the queued one, leaving the older KeyDown in place.
+	mustSend(t, host, keyDown("key", 1))
+	mustSend(t, host, keyUp("key", 1))
+	mustSend(t, host, keyUp("key", 2))
+	syncProbe(t, log, host, 1)
+	release()
+
+	assertLog(t, log.waitFor(t, "key#1:", 6),
+		"key#1:willAppear",
+		"key#1:waiting:0",
+		"key#1:keyDown:0",
+		"key#1:waiting:1",
+		"key#1:keyDown:1",
+		"key#1:keyUp:2",
+	)
+}
+
+func TestInstancedActionConcurrentContexts(t *testing.T) {
+	const (
+		contexts = 8
+		presses  = 50
+	)
+
+	log := newEventLog()
+	action := newRecordingAction(log, nil, streamdeck.WithQueue(streamdeck.QueueOptions{}))
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+
+	// Look up instances while they are being created and dispatched to.
+	done := make(chan struct{})
+	var lookups sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		lookups.Add(1)
+		go func() {
+			defer lookups.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				for _, instance := range action.Instances() {
+					_ = instance.EventContext()
+				}
+				_, _ = action.Instance("key0")
+			}
+		}()
+	}
+
+	var senders sync.WaitGroup
+	for i := 0; i < contexts; i++ {
+		eventContext := streamdeck.EventContext(fmt.Sprintf("key%d", i))
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+
+			if err := host.Send(willAppear(eventContext)); err != nil {
+				t.Errorf("sending event: %v", err)
+				return
+			}
+			for seq := 0; seq < presses; seq++ {
+				if err := host.Send(keyDown(eventContext, seq)); err != nil {
+					t.Errorf("sending event: %v", err)
+					return
+				}
+				if err := host.Send(keyUp(eventContext, seq)); err != nil {
+					t.Errorf("sending event: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	senders.Wait()
+
+	// Each instance sees its own events in the order they were sent, however they interleave with other contexts.
+	for i := 0; i < contexts; i++ {
+		prefix := fmt.Sprintf("key%d#1:", i)
+		got := log.waitFor(t, prefix, 1+2*presses)
+
+		want := []string{prefix + "willAppear"}
+		for seq := 0; seq < presses; seq++ {
+			want = append(want, fmt.Sprintf("%skeyDown:%d", prefix, seq), fmt.Sprintf("%skeyUp:%d", prefix, seq))
+		}
+		assertLog(t, got, want...)
+	}
+
+	close(done)
+	lookups.Wait()
+
+	if instances := action.Instances(); len(instances) != contexts {
+		t.Fatalf("expected %d instances, got %d", contexts, len(instances))
+	}
+}
+
+func TestInstancedActionBlockedContextDoesNotHoldOthers(t *testing.T) {
+	log := newEventLog()
+	gate := make(chan struct{})
+	action := newRecordingAction(log, gate, streamdeck.WithQueue(streamdeck.QueueOptions{}))
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+	release := releaseOnCleanup(t, gate)
+
+	mustSend(t, host, willAppear("slow"))
+	mustSend(t, host, willAppear("fast"))
+	mustSend(t, host, keyDown("slow", 0))
+	mustSend(t, host, keyUp("slow", 0))
+	log.waitFor(t, "slow#1:waiting:0", 1)
+	for seq := 0; seq < 10; seq++ {
+		mustSend(t, host, keyUp("fast", seq))
+	}
+
+	// The slow instance is stuck in its handler while the fast one handles every event.
+	log.waitFor(t, "fast#1:keyUp", 10)
+	assertLog(t, log.filter("slow#1:"), "slow#1:willAppear", "slow#1:waiting:0")
+
+	release()
+	assertLog(t, log.waitFor(t, "slow#1:", 4), "slow#1:willAppear", "slow#1:waiting:0", "slow#1:keyDown:0", "slow#1:keyUp:0")
+}
+
+func TestInstancedActionDrainHandlesQueuedEvents(t *testing.T) {
+	log := newEventLog()
+	gate := make(chan struct{})
+	action := newRecordingAction(log, gate, streamdeck.WithQueue(streamdeck.QueueOptions{}))
+	host, stop := servePlugin(t, streamdeck.NewPlugin(action))
+	release := releaseOnCleanup(t, gate)
+
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 0))
+	mustSend(t, host, keyUp("key", 0))
+	mustSend(t, host, keyUp("key", 1))
+	log.waitFor(t, "key#1:waiting:0", 1)
+
+	// Wait for the queued events to be read before shutting down.
+	mustSend(t, host, willAppear("probe"))
+	mustSend(t, host, keyUp("probe", 0))
+	log.waitFor(t, "probe#1:keyUp:0", 1)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		stop()
+	}()
+
+	// Drain waits for the instance to work through its queue.
+	select {
+	case <-stopped:
+		t.Fatal("expected Drain to wait for the queued events")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Drain")
+	}
+
+	assertLog(t, log.filter("key#1:"),
+		"key#1:willAppear",
+		"key#1:waiting:0",
+		"key#1:keyDown:0",
+		"key#1:keyUp:0",
+		"key#1:keyUp:1",
+		"key#1:dispose",
+	)
+}
@@ -0,0 +1,88 @@
+package streamdeck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// errRepliesNotRouted is returned when fetching through a publisher that doesn't belong to a Plugin, so no replies
+// can reach it.
+var errRepliesNotRouted = errors.New("replies are not routed to this publisher")
+
+func newReplyRouter() *replyRouter {
+	return &replyRouter{
+		waiters: make(map[replyKey][]chan json.RawMessage),
+	}
+}
+
+type replyKey struct {
+	eventName    EventName
+	eventContext EventContext
+}
+
+// replyRouter correlates the events sent in reply to requests, such as streamdeckevent.DidReceiveSettings in reply to
+// streamdeckevent.GetSettings, with the callers waiting on them. It is safe for concurrent use.
+type replyRouter struct {
+	mu      sync.Mutex
+	waiters map[replyKey][]chan json.RawMessage
+}
+
+// await sends the request and waits for the next reply with the event name and context. Every caller waiting on the
+// same reply receives it.
+func (r *replyRouter) await(ctx context.Context, eventName EventName, eventContext EventContext, send func() error) (json.RawMessage, error) {
+	key := replyKey{eventName: eventName, eventContext: eventContext}
+	ch := make(chan json.RawMessage, 1)
+
+	// The waiter is registered before sending so that a prompt reply isn't missed.
+	r.mu.Lock()
+	r.waiters[key] = append(r.waiters[key], ch)
+	r.mu.Unlock()
+
+	if err := send(); err != nil {
+		r.remove(key, ch)
+		return nil, err
+	}
+
+	select {
+	case raw := <-ch:
+		return raw, nil
+	case <-ctx.Done():
+		r.remove(key, ch)
+		return nil, ctx.Err()
+	}
+}
+
+// deliver hands the reply to everyone waiting on it.
+func (r *replyRouter) deliver(eventName EventName, eventContext EventContext, raw json.RawMessage) {
+	key := replyKey{eventName: eventName, eventContext: eventContext}
+
+	r.mu.Lock()
+	waiters := r.waiters[key]
+	delete(r.waiters, key)
+	r.mu.Unlock()
+
+	for _, ch := range waiters {
+		ch <- raw
+	}
+}
+
+func (r *replyRouter) remove(key replyKey, ch chan json.RawMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	waiters := r.waiters[key]
+	for i, existing := range waiters {
+		if existing == ch {
+			waiters = append(waiters[:i:i], waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiters) == 0 {
+		delete(r.waiters, key)
+	} else {
+		r.waiters[key] = waiters
+	}
+}
@@ -0,0 +1,107 @@
+package streamdeck
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// TypedActionInstanceFactory creates instances of an action whose settings are decoded into T.
+type TypedActionInstanceFactory[T any] func(eventContext EventContext, publisher ActionInstancePublisher, settings *Settings[T]) ActionInstance
+
+// NewTypedInstancedAction makes an InstancedAction whose instances keep their settings decoded into T. Each instance
+// is handed its own Settings, which is updated from the streamdeckevent.WillAppear and
+// streamdeckevent.DidReceiveSettings events before the instance handles them.
+func NewTypedInstancedAction[T any](actionUUID ActionUUID, createInstance TypedActionInstanceFactory[T], opts ...InstancedActionOption) *InstancedAction {
+	a := NewInstancedAction(actionUUID, nil, opts...)
+	a.create = func(eventContext EventContext, publisher ActionInstancePublisher) (ActionInstance, settingsDecoder) {
+		settings := NewSettings[T](publisher)
+		return createInstance(eventContext, publisher, settings), settings
+	}
+
+	return a
+}
+
+// settingsDecoder is implemented by Settings so an InstancedAction can update it from incoming events.
+type settingsDecoder interface {
+	Decode(raw json.RawMessage) error
+}
+
+// NewSettings makes a Settings that persists updates through the publisher.
+func NewSettings[T any](publisher ActionInstancePublisher) *Settings[T] {
+	return &Settings[T]{
+		publisher: publisher,
+	}
+}
+
+// Settings holds the settings of an action instance decoded into T. It is safe for concurrent use.
+type Settings[T any] struct {
+	publisher ActionInstancePublisher
+
+	mu    sync.RWMutex
+	value T
+}
+
+// Get returns the current settings. The value is a shallow copy, so maps and slices within it must not be modified;
+// use Update instead.
+func (s *Settings[T]) Get() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.value
+}
+
+// Set replaces the settings and persists them with the device.
+func (s *Settings[T]) Set(value T) error {
+	return s.Update(func(v *T) {
+		*v = value
+	})
+}
+
+// Update modifies a copy of the settings with fn and persists the result with the device. The current settings only
+// change once the streamdeckevent.SetSettings event has been written to the connection; the device doesn't
+// acknowledge it, so this doesn't mean the settings have been stored.
+func (s *Settings[T]) Update(fn func(*T)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value := s.value
+	fn(&value)
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshalling settings: %w", err)
+	}
+
+	if err = s.publisher.SetSettings(raw); err != nil {
+		return err
+	}
+
+	s.value = value
+	return nil
+}
+
+// Decode replaces the current settings with the raw settings received from the device. Empty settings reset the
+// value to the zero value of T.
+func (s *Settings[T]) Decode(raw json.RawMessage) error {
+	value, err := unmarshalSettings[T](raw)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.value = value
+	return nil
+}
+
+// unmarshalSettings decodes raw settings into T, treating empty settings as the zero value.
+func unmarshalSettings[T any](raw json.RawMessage) (T, error) {
+	var value T
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return value, fmt.Errorf("unmarshalling settings: %w", err)
+		}
+	}
+
+	return value, nil
+}
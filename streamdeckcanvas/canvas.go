@@ -0,0 +1,80 @@
+package streamdeckcanvas
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// ReferenceSize is the key size, in pixels, that font sizes and other measurements chosen by the user are relative to.
+// They are scaled to the size of a Canvas.
+const ReferenceSize = 72
+
+// New makes a transparent square Canvas of size pixels.
+func New(size int) *Canvas {
+	return &Canvas{
+		img: image.NewRGBA(image.Rect(0, 0, size, size)),
+	}
+}
+
+// Canvas is a key face being drawn. Each drawing method composites over what has already been drawn. A Canvas is not
+// safe for concurrent use.
+type Canvas struct {
+	img *image.RGBA
+}
+
+// Image returns the drawn key face.
+func (c *Canvas) Image() image.Image {
+	return c.img
+}
+
+// Size returns the width and height of the Canvas in pixels.
+func (c *Canvas) Size() int {
+	return c.img.Bounds().Dx()
+}
+
+// Bounds returns the rectangle covering the Canvas.
+func (c *Canvas) Bounds() image.Rectangle {
+	return c.img.Bounds()
+}
+
+// Fill replaces the entire Canvas with the color.
+func (c *Canvas) Fill(col color.Color) {
+	draw.Draw(c.img, c.img.Bounds(), image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// FillRect fills the rectangle with the color.
+func (c *Canvas) FillRect(r image.Rectangle, col color.Color) {
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+// DrawIcon scales the icon to fit within the Canvas, inset by padding pixels on every side, preserving its aspect
+// ratio and centering it.
+func (c *Canvas) DrawIcon(icon image.Image, padding int) {
+	c.DrawIconIn(icon, c.img.Bounds().Inset(padding))
+}
+
+// DrawIconIn scales the icon to fit within the rectangle, preserving its aspect ratio and centering it.
+func (c *Canvas) DrawIconIn(icon image.Image, r image.Rectangle) {
+	src := icon.Bounds()
+	if src.Empty() || r.Empty() {
+		return
+	}
+
+	w, h := r.Dx(), r.Dy()
+	if src.Dx()*h > src.Dy()*w {
+		h = src.Dy() * w / src.Dx()
+	} else {
+		w = src.Dx() * h / src.Dy()
+	}
+
+	dst := image.Rect(0, 0, w, h).Add(r.Min).Add(image.Pt((r.Dx()-w)/2, (r.Dy()-h)/2))
+	xdraw.CatmullRom.Scale(c.img, dst, icon, src, draw.Over, nil)
+}
+
+// scale converts a measurement relative to ReferenceSize to the size of the Canvas.
+func (c *Canvas) scale(v float64) float64 {
+	return v * float64(c.Size()) / ReferenceSize
+}
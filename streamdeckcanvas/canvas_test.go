@@ -0,0 +1,260 @@
+package streamdeckcanvas_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcanvas"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+var red = color.NRGBA{R: 0xff, A: 0xff}
+
+// assertPixel checks the color of the pixel at x, y.
+func assertPixel(t *testing.T, c *streamdeckcanvas.Canvas, x, y int, want color.Color) {
+	t.Helper()
+
+	if got := c.Image().At(x, y); !sameColor(got, want) {
+		t.Fatalf("expected the pixel at %d,%d to be %v, got %v", x, y, want, got)
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+// drawn returns the smallest rectangle covering the pixels that aren't transparent.
+func drawn(c *streamdeckcanvas.Canvas) image.Rectangle {
+	var r image.Rectangle
+	img := c.Image()
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0 {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return r
+}
+
+func TestNew(t *testing.T) {
+	for _, size := range []int{72, 144, 200} {
+		c := streamdeckcanvas.New(size)
+
+		if c.Size() != size {
+			t.Fatalf("expected size %d, got %d", size, c.Size())
+		}
+		if want := image.Rect(0, 0, size, size); c.Bounds() != want || c.Image().Bounds() != want {
+			t.Fatalf("expected bounds %v, got %v and an image of %v", want, c.Bounds(), c.Image().Bounds())
+		}
+		if r := drawn(c); !r.Empty() {
+			t.Fatalf("expected a transparent canvas, got pixels drawn in %v", r)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	c := streamdeckcanvas.New(72)
+	c.Fill(color.White)
+	c.Fill(red)
+
+	// Fill replaces what was drawn, rather than compositing over it.
+	c.Fill(color.NRGBA{B: 0xff, A: 0x80})
+	for _, p := range []image.Point{{0, 0}, {36, 36}, {71, 71}} {
+		assertPixel(t, c, p.X, p.Y, color.NRGBA{B: 0xff, A: 0x80})
+	}
+}
+
+func TestFillRect(t *testing.T) {
+	c := streamdeckcanvas.New(72)
+	c.Fill(color.Black)
+	c.FillRect(image.Rect(10, 10, 20, 20), red)
+
+	assertPixel(t, c, 10, 10, red)
+	assertPixel(t, c, 19, 19, red)
+	assertPixel(t, c, 9, 10, color.Black)
+	assertPixel(t, c, 20, 19, color.Black)
+}
+
+func TestDrawBar(t *testing.T) {
+	c := streamdeckcanvas.New(72)
+	c.DrawBar(image.Rect(0, 60, 72, 70), 0.5, streamdeckcanvas.BarStyle{Fill: red, Background: color.Black})
+
+	assertPixel(t, c, 0, 60, red)
+	assertPixel(t, c, 35, 69, red)
+	assertPixel(t, c, 36, 60, color.Black)
+	assertPixel(t, c, 71, 69, color.Black)
+	assertPixel(t, c, 0, 59, color.Transparent)
+}
+
+func TestDrawRing(t *testing.T) {
+	c := streamdeckcanvas.New(72)
+	c.DrawRing(0.25, streamdeckcanvas.RingStyle{Fill: red, Background: color.Black})
+
+	// The ring is 8 pixels thick along the edges, filled clockwise from the top for a quarter turn.
+	assertPixel(t, c, 36, 36, color.Transparent)
+	assertPixel(t, c, 37, 3, red)
+	assertPixel(t, c, 68, 35, red)
+	assertPixel(t, c, 35, 68, color.Black)
+	assertPixel(t, c, 3, 35, color.Black)
+}
+
+func TestDrawText(t *testing.T) {
+	testCases := []struct {
+		name      string
+		alignment streamdeckevent.VerticalAlignment
+		check     func(t *testing.T, r image.Rectangle)
+	}{
+		{
+			name:      "top",
+			alignment: streamdeckevent.Top,
+			check: func(t *testing.T, r image.Rectangle) {
+				if r.Min.Y > 12 || r.Max.Y > 36 {
+					t.Fatalf("expected the text at the top, got %v", r)
+				}
+			},
+		},
+		{
+			name: "middle",
+			check: func(t *testing.T, r image.Rectangle) {
+				if top, bottom := r.Min.Y, 72-r.Max.Y; top-bottom > 8 || bottom-top > 8 {
+					t.Fatalf("expected the text in the middle, got %v", r)
+				}
+			},
+		},
+		{
+			name:      "bottom",
+			alignment: streamdeckevent.Bottom,
+			check: func(t *testing.T, r image.Rectangle) {
+				if r.Min.Y < 36 || r.Max.Y < 60 {
+					t.Fatalf("expected the text at the bottom, got %v", r)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := streamdeckcanvas.New(72)
+			err := c.DrawText("Go", streamdeckcanvas.TextStyle{Size: 16, Color: red, Alignment: tc.alignment, Padding: 2})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			r := drawn(c)
+			if r.Empty() {
+				t.Fatal("expected text to be drawn")
+			}
+			if !r.In(c.Bounds().Inset(2)) {
+				t.Fatalf("expected the text within the padding, got %v", r)
+			}
+			if left, right := r.Min.X, 72-r.Max.X; left-right > 2 || right-left > 2 {
+				t.Fatalf("expected the text to be centered horizontally, got %v", r)
+			}
+			tc.check(t, r)
+		})
+	}
+}
+
+func TestDrawTextIn(t *testing.T) {
+	c := streamdeckcanvas.New(72)
+	area := image.Rect(0, 36, 72, 72)
+	if err := c.DrawTextIn("a long status line that needs wrapping", area, streamdeckcanvas.TextStyle{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Auto-fit text is wrapped and shrunk to fit the rectangle.
+	if r := drawn(c); r.Empty() || !r.In(area) {
+		t.Fatalf("expected text within %v, got %v", area, r)
+	}
+	assertPixel(t, c, 36, 10, color.Transparent)
+}
+
+func TestDrawTextScalesWithSize(t *testing.T) {
+	small, large := streamdeckcanvas.New(72), streamdeckcanvas.New(144)
+	for _, c := range []*streamdeckcanvas.Canvas{small, large} {
+		if err := c.DrawText("W", streamdeckcanvas.TextStyle{Size: 20}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// Font sizes are relative to ReferenceSize, so the text covers the same share of either canvas.
+	s, l := drawn(small), drawn(large)
+	if diff := l.Dx() - 2*s.Dx(); diff < -2 || diff > 2 {
+		t.Fatalf("expected the text to be twice as wide on a canvas twice the size, got %v and %v", s, l)
+	}
+}
+
+func TestDrawTitle(t *testing.T) {
+	params := streamdeckevent.TitleParameters{FontSize: 12, TitleAlignment: streamdeckevent.Top, TitleColor: "#ff0000"}
+
+	hidden := streamdeckcanvas.New(72)
+	if err := hidden.DrawTitle("Title", params); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if r := drawn(hidden); !r.Empty() {
+		t.Fatalf("expected a hidden title not to be drawn, got %v", r)
+	}
+
+	params.ShowTitle = true
+	c := streamdeckcanvas.New(72)
+	if err := c.DrawTitle("Title", params); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	r := drawn(c)
+	if r.Empty() || r.Max.Y > 36 {
+		t.Fatalf("expected the title at the top, got %v", r)
+	}
+
+	// The solid pixels of the glyphs take the title color.
+	found := false
+	for y := r.Min.Y; y < r.Max.Y && !found; y++ {
+		for x := r.Min.X; x < r.Max.X && !found; x++ {
+			found = sameColor(c.Image().At(x, y), red)
+		}
+	}
+	if !found {
+		t.Fatal("expected the title to be drawn in red")
+	}
+
+	params.TitleColor = "red"
+	if err := c.DrawTitle("Title", params); err == nil {
+		t.Fatal("expected an error for an invalid title color")
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	testCases := []struct {
+		in   streamdeckevent.Color
+		want color.Color
+	}{
+		{in: "#f00", want: red},
+		{in: "#ff0000", want: red},
+		{in: "#FF000080", want: color.NRGBA{R: 0xff, A: 0x80}},
+		{in: "00ff00", want: color.NRGBA{G: 0xff, A: 0xff}},
+		{in: "#ff00"},
+		{in: "#gg0000"},
+		{in: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.in), func(t *testing.T) {
+			got, err := streamdeckcanvas.ParseColor(tc.in)
+			if tc.want == nil {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+
+			if err != nil || !sameColor(got, tc.want) {
+				t.Fatalf("expected %v, got %v and %v", tc.want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package streamdeckcanvas
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// ParseColor parses a hex color such as "#fff", "#ffffff", or "#ffffffff", as used by the application.
+func ParseColor(c streamdeckevent.Color) (color.Color, error) {
+	s := strings.TrimPrefix(string(c), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		return nil, fmt.Errorf("invalid color %q", c)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q: %w", c, err)
+	}
+
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
@@ -0,0 +1,12 @@
+// Package streamdeckcanvas draws key faces, such as gauges, progress bars, and status text, in pure Go. A Canvas
+// produces an image.Image to publish with the streamdeckimage package.
+//
+//	c := streamdeckcanvas.New(streamdeckimage.KeySizeFor(info, device.Type))
+//	c.Fill(color.Black)
+//	c.DrawRing(0.75, streamdeckcanvas.RingStyle{Fill: color.White})
+//	c.DrawText("75%", streamdeckcanvas.TextStyle{Color: color.White})
+//	err := streamdeckimage.SetImage(publisher, c.Image(), streamdeckimage.Options{Size: c.Size()})
+//
+// Titles drawn with DrawTitle follow the streamdeckevent.TitleParameters the user chose for the key, as received with
+// the streamdeckevent.TitleParametersDidChange event.
+package streamdeckcanvas
@@ -0,0 +1,65 @@
+package streamdeckcanvas
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// FontStyle is the style of the embedded Go font text is drawn with.
+type FontStyle int
+
+const (
+	Regular FontStyle = iota
+	Bold
+	Italic
+	BoldItalic
+)
+
+var fontData = map[FontStyle][]byte{
+	Regular:    goregular.TTF,
+	Bold:       gobold.TTF,
+	Italic:     goitalic.TTF,
+	BoldItalic: gobolditalic.TTF,
+}
+
+var (
+	fontsOnce sync.Once
+	fonts     map[FontStyle]*opentype.Font
+	fontsErr  error
+)
+
+// newFace makes a face of the embedded font. Faces are not safe for concurrent use, so one is made for each drawing.
+func newFace(style FontStyle, size float64) (font.Face, error) {
+	fontsOnce.Do(func() {
+		fonts = make(map[FontStyle]*opentype.Font, len(fontData))
+		for s, data := range fontData {
+			f, err := opentype.Parse(data)
+			if err != nil {
+				fontsErr = fmt.Errorf("parsing embedded font: %w", err)
+				return
+			}
+			fonts[s] = f
+		}
+	})
+	if fontsErr != nil {
+		return nil, fontsErr
+	}
+
+	f, ok := fonts[style]
+	if !ok {
+		return nil, fmt.Errorf("unknown font style %d", style)
+	}
+
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
@@ -0,0 +1,188 @@
+package streamdeckcanvas
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// DefaultRingThickness is the thickness, relative to ReferenceSize, of a ring when RingStyle.Thickness is 0.
+const DefaultRingThickness = 8
+
+// RingStyle configures how a progress ring is drawn. Sizes are relative to ReferenceSize and scaled to the Canvas.
+type RingStyle struct {
+	// Thickness is the width of the ring. A Thickness of 0 uses DefaultRingThickness.
+	Thickness float64
+	// Padding is the space kept free between the ring and the edges of the Canvas.
+	Padding float64
+	// Fill is the color of the completed part of the ring. A nil Fill is white.
+	Fill color.Color
+	// Background is the color of the remaining part of the ring. A nil Background leaves it transparent.
+	Background color.Color
+}
+
+// DrawRing draws a ring around the center of the Canvas, filled clockwise from the top according to progress, which
+// ranges from 0 to 1.
+func (c *Canvas) DrawRing(progress float64, style RingStyle) {
+	progress = clamp(progress)
+
+	thickness := style.Thickness
+	if thickness <= 0 {
+		thickness = DefaultRingThickness
+	}
+
+	size := float64(c.Size())
+	center := size / 2
+	outer := center - c.scale(style.Padding)
+	inner := outer - c.scale(thickness)
+	if outer <= 0 {
+		return
+	}
+
+	bounds := c.img.Bounds()
+	fillMask := image.NewAlpha(bounds)
+	backgroundMask := image.NewAlpha(bounds)
+	sweep := progress * 2 * math.Pi
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dx, dy := float64(x)+0.5-center, float64(y)+0.5-center
+			d := math.Hypot(dx, dy)
+			coverage := clamp(outer-d+0.5) * clamp(d-inner+0.5)
+			if coverage == 0 {
+				continue
+			}
+
+			// The angle is measured clockwise from the top.
+			angle := math.Atan2(dx, -dy)
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+
+			alpha := color.Alpha{A: uint8(coverage * 0xff)}
+			if angle <= sweep {
+				fillMask.SetAlpha(x, y, alpha)
+			} else {
+				backgroundMask.SetAlpha(x, y, alpha)
+			}
+		}
+	}
+
+	if style.Background != nil {
+		draw.DrawMask(c.img, bounds, image.NewUniform(style.Background), image.Point{}, backgroundMask, bounds.Min, draw.Over)
+	}
+
+	fill := style.Fill
+	if fill == nil {
+		fill = color.White
+	}
+	draw.DrawMask(c.img, bounds, image.NewUniform(fill), image.Point{}, fillMask, bounds.Min, draw.Over)
+}
+
+// BarStyle configures how a progress bar is drawn.
+type BarStyle struct {
+	// Fill is the color of the completed part of the bar. A nil Fill is white.
+	Fill color.Color
+	// Background is the color of the remaining part of the bar. A nil Background leaves it transparent.
+	Background color.Color
+}
+
+// DrawBar draws a horizontal bar in the rectangle, filled from the left according to progress, which ranges from 0
+// to 1.
+func (c *Canvas) DrawBar(r image.Rectangle, progress float64, style BarStyle) {
+	progress = clamp(progress)
+
+	if style.Background != nil {
+		c.FillRect(r, style.Background)
+	}
+
+	fill := style.Fill
+	if fill == nil {
+		fill = color.White
+	}
+
+	filled := r
+	filled.Max.X = r.Min.X + int(math.Round(progress*float64(r.Dx())))
+	c.FillRect(filled, fill)
+}
+
+// Corner is a corner of the Canvas.
+type Corner int
+
+const (
+	TopRight Corner = iota
+	TopLeft
+	BottomRight
+	BottomLeft
+)
+
+// DefaultBadgeRadius is the radius, relative to ReferenceSize, of a badge when BadgeStyle.Radius is 0.
+const DefaultBadgeRadius = 12
+
+// BadgeStyle configures how a badge is drawn. Sizes are relative to ReferenceSize and scaled to the Canvas.
+type BadgeStyle struct {
+	// Radius is the radius of the badge. A Radius of 0 uses DefaultBadgeRadius.
+	Radius float64
+	// Corner is the corner of the Canvas the badge is drawn in.
+	Corner Corner
+	// Color is the color of the badge. A nil Color is red.
+	Color color.Color
+	// TextColor is the color of the text. A nil TextColor is white.
+	TextColor color.Color
+}
+
+// DrawBadge draws a circular badge, such as a count of notifications, in a corner of the Canvas. The text is fit
+// within the badge and may be empty to draw a plain dot.
+func (c *Canvas) DrawBadge(text string, style BadgeStyle) error {
+	radius := style.Radius
+	if radius <= 0 {
+		radius = DefaultBadgeRadius
+	}
+	radius = c.scale(radius)
+
+	size := float64(c.Size())
+	inset := c.scale(1)
+	cx, cy := size-radius-inset, radius+inset
+	switch style.Corner {
+	case TopLeft:
+		cx = radius + inset
+	case BottomRight:
+		cy = size - radius - inset
+	case BottomLeft:
+		cx, cy = radius+inset, size-radius-inset
+	}
+
+	col := style.Color
+	if col == nil {
+		col = color.NRGBA{R: 0xe0, G: 0x24, B: 0x24, A: 0xff}
+	}
+
+	bounds := c.img.Bounds()
+	mask := image.NewAlpha(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			d := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+			if coverage := clamp(radius - d + 0.5); coverage > 0 {
+				mask.SetAlpha(x, y, color.Alpha{A: uint8(coverage * 0xff)})
+			}
+		}
+	}
+	draw.DrawMask(c.img, bounds, image.NewUniform(col), image.Point{}, mask, bounds.Min, draw.Over)
+
+	if text == "" {
+		return nil
+	}
+
+	// The text is fit within the square inscribed in the badge.
+	half := radius / math.Sqrt2
+	r := image.Rect(int(math.Round(cx-half)), int(math.Round(cy-half)), int(math.Round(cx+half)), int(math.Round(cy+half)))
+	return c.DrawTextIn(text, r, TextStyle{
+		Font:    Bold,
+		MinSize: 1,
+		Color:   style.TextColor,
+	})
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
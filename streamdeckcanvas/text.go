@@ -0,0 +1,221 @@
+package streamdeckcanvas
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+const (
+	// DefaultMinTextSize is the smallest font size, relative to ReferenceSize, auto-fit text shrinks to.
+	DefaultMinTextSize = 6
+	// DefaultMaxTextSize is the largest font size, relative to ReferenceSize, auto-fit text grows to.
+	DefaultMaxTextSize = 28
+)
+
+// TextStyle configures how text is drawn. Sizes are relative to ReferenceSize and scaled to the Canvas.
+type TextStyle struct {
+	// Font is the style of the embedded Go font.
+	Font FontStyle
+	// Size is the font size. A Size of 0 fits the text to the available space, between MinSize and MaxSize.
+	Size float64
+	// MinSize is the smallest font size auto-fit text shrinks to. A MinSize of 0 uses DefaultMinTextSize.
+	MinSize float64
+	// MaxSize is the largest font size auto-fit text grows to. A MaxSize of 0 uses DefaultMaxTextSize.
+	MaxSize float64
+	// Color is the color of the text. A nil Color draws white text.
+	Color color.Color
+	// Alignment positions the text vertically. An empty Alignment centers the text.
+	Alignment streamdeckevent.VerticalAlignment
+	// Underline draws a line beneath each line of text.
+	Underline bool
+	// Padding is the space kept free along each edge.
+	Padding float64
+}
+
+// TitleTextStyle makes the TextStyle matching the title parameters chosen by the user. Only the embedded Go font is
+// available, so the font family is ignored while the font style is honored.
+func TitleTextStyle(params streamdeckevent.TitleParameters) (TextStyle, error) {
+	style := TextStyle{
+		Size:      float64(params.FontSize),
+		Alignment: params.TitleAlignment,
+		Underline: params.FontUnderline,
+		Padding:   2,
+	}
+
+	switch strings.ToLower(params.FontStyle) {
+	case "bold":
+		style.Font = Bold
+	case "italic":
+		style.Font = Italic
+	case "bold italic":
+		style.Font = BoldItalic
+	}
+
+	if params.TitleColor != "" {
+		col, err := ParseColor(params.TitleColor)
+		if err != nil {
+			return TextStyle{}, err
+		}
+		style.Color = col
+	}
+
+	return style, nil
+}
+
+// DrawTitle draws the title as the application would, following the title parameters chosen by the user. Nothing is
+// drawn when the user has hidden the title.
+func (c *Canvas) DrawTitle(title string, params streamdeckevent.TitleParameters) error {
+	if !params.ShowTitle {
+		return nil
+	}
+
+	style, err := TitleTextStyle(params)
+	if err != nil {
+		return err
+	}
+
+	return c.DrawText(title, style)
+}
+
+// DrawText draws the text across the Canvas, wrapping it at spaces and line breaks and centering each line
+// horizontally.
+func (c *Canvas) DrawText(text string, style TextStyle) error {
+	return c.DrawTextIn(text, c.img.Bounds(), style)
+}
+
+// DrawTextIn draws the text within the rectangle, wrapping it at spaces and line breaks and centering each line
+// horizontally. Text that doesn't fit is clipped.
+func (c *Canvas) DrawTextIn(text string, r image.Rectangle, style TextStyle) error {
+	padding := int(math.Round(c.scale(style.Padding)))
+	r = r.Inset(padding)
+	if r.Empty() {
+		return nil
+	}
+
+	face, lines, err := c.layoutText(text, r, style)
+	if err != nil {
+		return err
+	}
+	defer face.Close()
+
+	col := style.Color
+	if col == nil {
+		col = color.White
+	}
+
+	metrics := face.Metrics()
+	lineHeight := metrics.Height.Ceil()
+	y := r.Min.Y
+	switch style.Alignment {
+	case streamdeckevent.Top:
+	case streamdeckevent.Bottom:
+		y = r.Max.Y - len(lines)*lineHeight
+	default:
+		y += (r.Dy() - len(lines)*lineHeight) / 2
+	}
+
+	d := font.Drawer{
+		Dst:  c.img.SubImage(r).(*image.RGBA),
+		Src:  image.NewUniform(col),
+		Face: face,
+	}
+
+	for i, line := range lines {
+		width := d.MeasureString(line)
+		baseline := y + i*lineHeight + metrics.Ascent.Ceil()
+		x := r.Min.X + (r.Dx()-width.Ceil())/2
+
+		d.Dot = fixed.P(x, baseline)
+		d.DrawString(line)
+
+		if style.Underline && line != "" {
+			thickness := int(math.Max(1, math.Round(float64(metrics.Height.Ceil())/16)))
+			top := baseline + metrics.Descent.Ceil()/2
+			c.FillRect(image.Rect(x, top, x+width.Ceil(), top+thickness).Intersect(r), col)
+		}
+	}
+
+	return nil
+}
+
+// layoutText picks the face for the style, shrinking auto-fit text until it fits, and wraps the text into lines.
+func (c *Canvas) layoutText(text string, r image.Rectangle, style TextStyle) (font.Face, []string, error) {
+	if style.Size > 0 {
+		face, err := newFace(style.Font, c.scale(style.Size))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return face, wrapText(face, text, r.Dx()), nil
+	}
+
+	minSize, maxSize := style.MinSize, style.MaxSize
+	if minSize <= 0 {
+		minSize = DefaultMinTextSize
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxTextSize
+	}
+
+	minSize, maxSize = math.Max(1, math.Round(c.scale(minSize))), math.Round(c.scale(maxSize))
+	for size := maxSize; ; size-- {
+		face, err := newFace(style.Font, size)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		lines := wrapText(face, text, r.Dx())
+		if size <= minSize || fitsText(face, lines, r) {
+			return face, lines, nil
+		}
+
+		_ = face.Close()
+	}
+}
+
+// wrapText breaks the text into lines no wider than width, breaking at spaces and line breaks. A word wider than
+// width is kept whole on its own line.
+func wrapText(face font.Face, text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+
+			if line != "" && font.MeasureString(face, candidate).Ceil() > width {
+				lines = append(lines, line)
+				line = word
+			} else {
+				line = candidate
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func fitsText(face font.Face, lines []string, r image.Rectangle) bool {
+	if len(lines)*face.Metrics().Height.Ceil() > r.Dy() {
+		return false
+	}
+
+	for _, line := range lines {
+		if font.MeasureString(face, line).Ceil() > r.Dx() {
+			return false
+		}
+	}
+
+	return true
+}
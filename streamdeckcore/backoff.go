@@ -0,0 +1,44 @@
+package streamdeckcore
+
+import (
+	"time"
+)
+
+// Backoff configures the exponential delay between attempts to re-establish a dropped connection.
+type Backoff struct {
+	// InitialDelay is the delay before the first reconnection attempt.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between reconnection attempts.
+	MaxDelay time.Duration
+	// Multiplier is applied to the delay after each failed attempt.
+	Multiplier float64
+	// MaxAttempts is the number of attempts made before giving up. Zero means retry forever.
+	MaxAttempts int
+}
+
+// DefaultBackoff returns the Backoff used by ParseConfig.
+func DefaultBackoff() *Backoff {
+	return &Backoff{
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+		MaxAttempts:  10,
+	}
+}
+
+// Delay returns the delay before the given attempt, starting at 0.
+func (b *Backoff) Delay(attempt int) time.Duration {
+	delay := float64(b.InitialDelay)
+	for i := 0; i < attempt; i++ {
+		delay *= b.Multiplier
+		if b.MaxDelay > 0 && delay >= float64(b.MaxDelay) {
+			return b.MaxDelay
+		}
+	}
+
+	if b.MaxDelay > 0 && delay > float64(b.MaxDelay) {
+		return b.MaxDelay
+	}
+
+	return time.Duration(delay)
+}
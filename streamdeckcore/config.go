@@ -1,8 +1,10 @@
 package streamdeckcore
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"log/slog"
 )
 
 // Config holds the launch configuration for a plugin.
@@ -10,7 +12,24 @@ type Config struct {
 	Port          int
 	PluginUUID    PluginUUID
 	RegisterEvent EventName
-	Info          string
+	Info          RegistrationInfo
+
+	// Reconnect configures how a dropped connection is re-established. A nil Reconnect disables reconnection.
+	Reconnect *Backoff
+
+	// Logger receives the logs of Serve. A nil Logger uses slog.Default().
+	Logger *slog.Logger
+	// LogPayloads includes the raw events in the debug logs of the events sent and received. As events may contain
+	// secrets kept in settings, they are left out by default.
+	LogPayloads bool
+}
+
+func (cfg *Config) logger() *slog.Logger {
+	if cfg.Logger != nil {
+		return cfg.Logger
+	}
+
+	return slog.Default()
 }
 
 // ParseConfig parses the configuration from the provide arguments.
@@ -39,10 +58,16 @@ func ParseConfig(args []string) (*Config, error) {
 		return nil, fmt.Errorf("missing -info flag")
 	}
 
+	var registrationInfo RegistrationInfo
+	if err := json.Unmarshal([]byte(*info), &registrationInfo); err != nil {
+		return nil, fmt.Errorf("parsing -info flag: %w", err)
+	}
+
 	return &Config{
 		Port:          *port,
 		PluginUUID:    PluginUUID(*pluginUUID),
 		RegisterEvent: EventName(*registerEvent),
-		Info:          *info,
+		Info:          registrationInfo,
+		Reconnect:     DefaultBackoff(),
 	}, nil
 }
@@ -0,0 +1,152 @@
+package streamdeckcore_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+)
+
+// info is an -info argument as the application passes it on a Mac with a Stream Deck and a Stream Deck + connected.
+const info = `{
+	"application": {
+		"font": ".AppleSystemUIFont",
+		"language": "en",
+		"platform": "mac",
+		"platformVersion": "13.4.1",
+		"version": "6.4.0.19383"
+	},
+	"plugin": {
+		"uuid": "com.elgato.hello-world",
+		"version": "1.0.0.0"
+	},
+	"devicePixelRatio": 2,
+	"colors": {
+		"buttonPressedBackgroundColor": "#303030FF",
+		"buttonPressedBorderColor": "#646464FF",
+		"buttonPressedTextColor": "#969696FF",
+		"disabledColor": "#F7821B59",
+		"highlightColor": "#F7821BFF",
+		"mouseDownColor": "#CF6304FF"
+	},
+	"devices": [
+		{
+			"id": "55F16B35884A859CCE4FFA1FC8D3DE5B",
+			"name": "Stream Deck",
+			"size": {"columns": 5, "rows": 3},
+			"type": 0
+		},
+		{
+			"id": "A2C1D4E8F1B2C3D4E5F6A7B8C9D0E1F2",
+			"name": "Stream Deck +",
+			"size": {"columns": 4, "rows": 2},
+			"type": 7
+		}
+	]
+}`
+
+func args(info string) []string {
+	return []string{"plugin", "-port", "28196", "-pluginUUID", "6F4C1A3B2E5D", "-registerEvent", "registerPlugin", "-info", info}
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := streamdeckcore.ParseConfig(args(info))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cfg.Port != 28196 || cfg.PluginUUID != "6F4C1A3B2E5D" || cfg.RegisterEvent != "registerPlugin" {
+		t.Fatalf("expected the flags to be parsed, got port %d, plugin UUID %q, and register event %q", cfg.Port, cfg.PluginUUID, cfg.RegisterEvent)
+	}
+
+	want := streamdeckcore.RegistrationInfo{
+		Application: streamdeckcore.ApplicationInfo{
+			Font:            ".AppleSystemUIFont",
+			Language:        "en",
+			Platform:        streamdeckcore.PlatformMac,
+			PlatformVersion: "13.4.1",
+			Version:         "6.4.0.19383",
+		},
+		Plugin: streamdeckcore.PluginInfo{
+			UUID:    "com.elgato.hello-world",
+			Version: "1.0.0.0",
+		},
+		DevicePixelRatio: 2,
+		Colors: streamdeckcore.ColorInfo{
+			ButtonPressedBackgroundColor: "#303030FF",
+			ButtonPressedBorderColor:     "#646464FF",
+			ButtonPressedTextColor:       "#969696FF",
+			DisabledColor:                "#F7821B59",
+			HighlightColor:               "#F7821BFF",
+			MouseDownColor:               "#CF6304FF",
+		},
+		Devices: []streamdeckcore.RegisteredDevice{
+			{
+				ID: "55F16B35884A859CCE4FFA1FC8D3DE5B",
+				DeviceInfo: streamdeckcore.DeviceInfo{
+					Type:       streamdeckcore.StreamDeck,
+					Size:       streamdeckcore.DeviceSize{Columns: 5, Rows: 3},
+					DeviceName: "Stream Deck",
+				},
+			},
+			{
+				ID: "A2C1D4E8F1B2C3D4E5F6A7B8C9D0E1F2",
+				DeviceInfo: streamdeckcore.DeviceInfo{
+					Type:       streamdeckcore.StreamDeckPlus,
+					Size:       streamdeckcore.DeviceSize{Columns: 4, Rows: 2},
+					DeviceName: "Stream Deck +",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(cfg.Info, want) {
+		t.Fatalf("expected %+v, got %+v", want, cfg.Info)
+	}
+
+	device, ok := cfg.Info.Device("A2C1D4E8F1B2C3D4E5F6A7B8C9D0E1F2")
+	if !ok || device.Type != streamdeckcore.StreamDeckPlus {
+		t.Fatalf("expected to find the Stream Deck +, got %+v", device)
+	}
+	if _, ok := cfg.Info.Device("unknown"); ok {
+		t.Fatal("expected no unknown device")
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "malformed info",
+			args: args(`{"application": {"platform": "mac"`),
+			err:  "parsing -info flag: unexpected end of JSON input",
+		},
+		{
+			name: "mistyped info",
+			args: args(`{"devices": [{"id": "55F16B35884A859CCE4FFA1FC8D3DE5B", "type": "keypad"}]}`),
+			err:  "parsing -info flag: json: cannot unmarshal string",
+		},
+		{
+			name: "missing info",
+			args: []string{"plugin", "-port", "28196", "-pluginUUID", "6F4C1A3B2E5D", "-registerEvent", "registerPlugin"},
+			err:  "missing -info flag",
+		},
+		{
+			name: "missing port",
+			args: []string{"plugin", "-pluginUUID", "6F4C1A3B2E5D", "-registerEvent", "registerPlugin", "-info", info},
+			err:  "missing -port flag",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := streamdeckcore.ParseConfig(tc.args)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("expected error starting with %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
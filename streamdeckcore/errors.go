@@ -0,0 +1,43 @@
+package streamdeckcore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// ConnectionClosedError is returned by Serve when the connection to the device is lost and is not re-established.
+type ConnectionClosedError struct {
+	// Code is the websocket close code. It is websocket.CloseAbnormalClosure when the connection was lost without
+	// receiving a close frame.
+	Code int
+	// Text is the close reason provided by the device, if any.
+	Text string
+	// Err is the error that ended the connection.
+	Err error
+}
+
+func newConnectionClosedError(err error) *ConnectionClosedError {
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return &ConnectionClosedError{
+			Code: closeErr.Code,
+			Text: closeErr.Text,
+			Err:  err,
+		}
+	}
+
+	return &ConnectionClosedError{
+		Code: websocket.CloseAbnormalClosure,
+		Err:  err,
+	}
+}
+
+func (e *ConnectionClosedError) Error() string {
+	return fmt.Sprintf("connection closed (%d): %v", e.Code, e.Err)
+}
+
+func (e *ConnectionClosedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,90 @@
+package streamdeckcore
+
+// RegistrationInfo describes the application, the plugin, and the connected devices at the time the plugin was
+// launched. It is parsed from the -info launch argument.
+type RegistrationInfo struct {
+	Application      ApplicationInfo    `json:"application"`
+	Plugin           PluginInfo         `json:"plugin"`
+	DevicePixelRatio int                `json:"devicePixelRatio,omitempty"`
+	Colors           ColorInfo          `json:"colors"`
+	Devices          []RegisteredDevice `json:"devices,omitempty"`
+}
+
+// Device returns the registered device with the UUID, if one exists.
+func (i *RegistrationInfo) Device(deviceUUID DeviceUUID) (RegisteredDevice, bool) {
+	for _, device := range i.Devices {
+		if device.ID == deviceUUID {
+			return device, true
+		}
+	}
+
+	return RegisteredDevice{}, false
+}
+
+// ApplicationInfo describes the application hosting the plugin.
+type ApplicationInfo struct {
+	Font            string   `json:"font,omitempty"`
+	Language        string   `json:"language,omitempty"`
+	Platform        Platform `json:"platform,omitempty"`
+	PlatformVersion string   `json:"platformVersion,omitempty"`
+	Version         string   `json:"version,omitempty"`
+}
+
+// Platform is the operating system the application runs on.
+type Platform string
+
+const (
+	PlatformMac     Platform = "mac"
+	PlatformWindows Platform = "windows"
+)
+
+// PluginInfo describes the plugin as registered with the application.
+type PluginInfo struct {
+	UUID    string `json:"uuid,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// ColorInfo holds the colors of the application's color scheme, as hex strings like "#969696FF".
+type ColorInfo struct {
+	ButtonPressedBackgroundColor string `json:"buttonPressedBackgroundColor,omitempty"`
+	ButtonPressedBorderColor     string `json:"buttonPressedBorderColor,omitempty"`
+	ButtonPressedTextColor       string `json:"buttonPressedTextColor,omitempty"`
+	DisabledColor                string `json:"disabledColor,omitempty"`
+	HighlightColor               string `json:"highlightColor,omitempty"`
+	MouseDownColor               string `json:"mouseDownColor,omitempty"`
+}
+
+// RegisteredDevice is a device connected when the plugin was launched.
+type RegisteredDevice struct {
+	ID DeviceUUID `json:"id"`
+	DeviceInfo
+}
+
+// DeviceInfo is the device information provided by the device.
+type DeviceInfo struct {
+	Type       DeviceType `json:"type,omitempty"`
+	Size       DeviceSize `json:"size,omitempty"`
+	DeviceName DeviceName `json:"name,omitempty"`
+}
+
+// DeviceSize is the size of a Streamdeck.
+type DeviceSize struct {
+	Columns int `json:"columns,omitempty"`
+	Rows    int `json:"rows,omitempty"`
+}
+
+// DeviceType is the type of device.
+type DeviceType int
+
+const (
+	StreamDeck       DeviceType = 0
+	StreamDeckMini   DeviceType = 1
+	StreamDeckXL     DeviceType = 2
+	StreamDeckMobile DeviceType = 3
+	CorsairGKeys     DeviceType = 4
+	StreamDeckPedal  DeviceType = 5
+	CorsairVoyager   DeviceType = 6
+	StreamDeckPlus   DeviceType = 7
+	SCUFController   DeviceType = 8
+	StreamDeckNeo    DeviceType = 9
+)
@@ -0,0 +1,34 @@
+package streamdeckcore
+
+import (
+	"encoding/json"
+	"log/slog"
+)
+
+// eventAttrs describes a raw event for logging. The payload is only included when logPayloads is set, as it may
+// contain secrets kept in settings.
+func eventAttrs(raw json.RawMessage, logPayloads bool) []any {
+	var eventHeader struct {
+		Event   EventName    `json:"event"`
+		Action  ActionUUID   `json:"action"`
+		Context EventContext `json:"context"`
+		Device  DeviceUUID   `json:"device"`
+	}
+	_ = json.Unmarshal(raw, &eventHeader)
+
+	attrs := []any{slog.String("event", string(eventHeader.Event))}
+	if eventHeader.Action != "" {
+		attrs = append(attrs, slog.String("action", string(eventHeader.Action)))
+	}
+	if eventHeader.Context != "" {
+		attrs = append(attrs, slog.String("context", string(eventHeader.Context)))
+	}
+	if eventHeader.Device != "" {
+		attrs = append(attrs, slog.String("device", string(eventHeader.Device)))
+	}
+	if logPayloads {
+		attrs = append(attrs, slog.String("payload", string(raw)))
+	}
+
+	return attrs
+}
@@ -3,9 +3,11 @@ package streamdeckcore
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -18,7 +20,33 @@ type Handler interface {
 // Plugin is implemented by a plugin in order to interact with a device.
 type Plugin interface {
 	Handler
-	Initialize(pluginUUID PluginUUID, publisher Publisher)
+	Initialize(pluginUUID PluginUUID, info RegistrationInfo, publisher Publisher)
+}
+
+// ConnectedHandler is optionally implemented by a Plugin that wishes to know when it has registered with the device,
+// both initially and after each reconnection, for instance to request state from the device. It is called alongside
+// the receipt of events, so it may wait for the device to reply.
+type ConnectedHandler interface {
+	HandleConnected(ctx context.Context) error
+}
+
+// DisconnectedHandler is optionally implemented by a Plugin that wishes to know when the connection to the device
+// has been lost. Publishing while disconnected fails.
+type DisconnectedHandler interface {
+	HandleDisconnected(ctx context.Context, err error) error
+}
+
+// ReconnectedHandler is optionally implemented by a Plugin that wishes to know when the connection to the device
+// has been re-established and the plugin re-registered. Like ConnectedHandler, it is called alongside the receipt of
+// events.
+type ReconnectedHandler interface {
+	HandleReconnected(ctx context.Context) error
+}
+
+// Drainer is optionally implemented by a Plugin that handles events asynchronously. Serve calls Drain before it
+// returns so that in-flight handlers finish.
+type Drainer interface {
+	Drain()
 }
 
 // Publisher is provided to Plugins so they can communicate with a device.
@@ -26,52 +54,99 @@ type Publisher interface {
 	PublishEvent(raw json.RawMessage) error
 }
 
-// Serve wraps a websocket to handle receiving and publishing events. To shutdown, cancel the provided context.
+// Serve wraps a websocket to handle receiving and publishing events. When the connection drops and cfg.Reconnect
+// is set, Serve reconnects using the configured backoff and registers the plugin again. When the connection cannot
+// be re-established, Serve cancels the context handed to handlers, waits for in-flight handlers to return, and
+// returns a *ConnectionClosedError. To shutdown, cancel the provided context.
 func Serve(ctx context.Context, cfg *Config, plugin Plugin) error {
 	url := fmt.Sprintf("ws://127.0.0.1:%d", cfg.Port)
-	log.Printf("[core] pluginUUID %q connecting to %s", cfg.PluginUUID, url)
+	logger := cfg.logger()
+	logger.Info("connecting", slog.String("plugin", string(cfg.PluginUUID)), slog.String("url", url))
 
-	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	c, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
 	if err != nil {
 		return fmt.Errorf("dialing %s: %w", url, err)
 	}
 
-	var publishLock sync.Mutex
-	publishFunc := eventPublisherFunc(func(raw json.RawMessage) error {
-		publishLock.Lock()
-		defer publishLock.Unlock()
-		log.Printf("[core] sending message %v", string(raw))
-		if err := c.WriteMessage(websocket.TextMessage, raw); err != nil {
-			return fmt.Errorf("sending event: %w", err)
+	publisher := &connPublisher{
+		logger:      logger,
+		logPayloads: cfg.LogPayloads,
+	}
+	publisher.setConn(c)
+	plugin.Initialize(cfg.PluginUUID, cfg.Info, publisher)
+
+	handlerCtx, cancel := context.WithCancel(ctx)
+	var connecting sync.WaitGroup
+	defer func() {
+		cancel()
+		connecting.Wait()
+		if d, ok := plugin.(Drainer); ok {
+			d.Drain()
 		}
+	}()
 
-		return nil
-	})
-	plugin.Initialize(cfg.PluginUUID, publishFunc)
+	reconnected := false
+	for {
+		if err := register(cfg, publisher); err != nil {
+			_ = c.Close()
+			return err
+		}
 
-	go func() {
-		for {
-			mt, msg, err := c.ReadMessage()
-			if err != nil {
-				log.Printf("[core] ERROR receiving event: %v", err)
-				return
-			}
+		// The handlers run while events are received, so that they may wait for replies from the device.
+		connecting.Add(1)
+		go func(reconnected bool) {
+			defer connecting.Done()
+			handleConnected(handlerCtx, logger, plugin, reconnected)
+		}(reconnected)
 
-			if mt == websocket.PingMessage {
-				if err := c.WriteMessage(websocket.PongMessage, []byte{}); err != nil {
-					log.Printf("error while ponging: %v\n", err)
-				}
-				continue
+		err := receive(handlerCtx, cfg, c, plugin)
+		publisher.setConn(nil)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		logger.Error("receiving event", slog.Any("error", err))
+		closedErr := newConnectionClosedError(err)
+		if h, ok := plugin.(DisconnectedHandler); ok {
+			if err := h.HandleDisconnected(handlerCtx, closedErr); err != nil {
+				logger.Error("handling disconnect", slog.Any("error", err))
 			}
+		}
 
-			log.Printf("[core] received message: %s", string(msg))
+		if cfg.Reconnect == nil {
+			return closedErr
+		}
 
-			if err = plugin.HandleEvent(ctx, msg); err != nil {
-				log.Printf("[core] ERROR handling event: %v", err)
+		if c, err = reconnect(ctx, logger, url, cfg.Reconnect); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
+
+			logger.Error("reconnecting", slog.Any("error", err))
+			return closedErr
 		}
-	}()
 
+		publisher.setConn(c)
+		reconnected = true
+	}
+}
+
+// handleConnected notifies the plugin that it has registered with the device.
+func handleConnected(ctx context.Context, logger *slog.Logger, plugin Plugin, reconnected bool) {
+	if h, ok := plugin.(ConnectedHandler); ok {
+		if err := h.HandleConnected(ctx); err != nil {
+			logger.Error("handling connect", slog.Any("error", err))
+		}
+	}
+
+	if h, ok := plugin.(ReconnectedHandler); ok && reconnected {
+		if err := h.HandleReconnected(ctx); err != nil {
+			logger.Error("handling reconnect", slog.Any("error", err))
+		}
+	}
+}
+
+func register(cfg *Config, publisher Publisher) error {
 	type registerEvent struct {
 		PluginUUID PluginUUID `json:"uuid,omitempty"`
 		Event      EventName  `json:"event,omitempty"`
@@ -82,18 +157,101 @@ func Serve(ctx context.Context, cfg *Config, plugin Plugin) error {
 		Event:      cfg.RegisterEvent,
 	})
 
-	if err := publishFunc(raw); err != nil {
-		log.Printf("[core] ERROR registering plugin: %v", err)
+	if err := publisher.PublishEvent(raw); err != nil {
+		cfg.logger().Error("registering plugin", slog.Any("error", err))
 		return fmt.Errorf("registering plugin: %w", err)
 	}
 
-	<-ctx.Done()
-	_ = c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-	return ctx.Err()
+	return nil
 }
 
-type eventPublisherFunc func(raw json.RawMessage) error
+// receive reads events from the connection until it fails or the context is cancelled.
+func receive(ctx context.Context, cfg *Config, c *websocket.Conn, plugin Plugin) error {
+	logger := cfg.logger()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(time.Second))
+			_ = c.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			_ = c.Close()
+			return err
+		}
+
+		logger.Debug("received event", eventAttrs(msg, cfg.LogPayloads)...)
+
+		if err = plugin.HandleEvent(ctx, msg); err != nil {
+			logger.Error("handling event", append(eventAttrs(msg, false), slog.Any("error", err))...)
+		}
+	}
+}
+
+// reconnect dials the url until it succeeds, the backoff gives up, or the context is cancelled.
+func reconnect(ctx context.Context, logger *slog.Logger, url string, backoff *Backoff) (*websocket.Conn, error) {
+	for attempt := 0; backoff.MaxAttempts == 0 || attempt < backoff.MaxAttempts; attempt++ {
+		delay := backoff.Delay(attempt)
+		logger.Info("reconnecting", slog.String("url", url), slog.Duration("delay", delay), slog.Int("attempt", attempt+1))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		c, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+		if err == nil {
+			return c, nil
+		}
+
+		logger.Warn("reconnecting failed", slog.String("url", url), slog.Int("attempt", attempt+1), slog.Any("error", err))
+	}
+
+	return nil, fmt.Errorf("reconnecting to %s: gave up after %d attempts", url, backoff.MaxAttempts)
+}
+
+var errNotConnected = errors.New("not connected")
+
+// connPublisher publishes events to the current connection, which changes as the connection is re-established.
+type connPublisher struct {
+	logger      *slog.Logger
+	logPayloads bool
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (p *connPublisher) setConn(c *websocket.Conn) {
+	p.mu.Lock()
+	p.conn = c
+	p.mu.Unlock()
+}
+
+func (p *connPublisher) PublishEvent(raw json.RawMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return fmt.Errorf("sending event: %w", errNotConnected)
+	}
+
+	p.logger.Debug("sending event", eventAttrs(raw, p.logPayloads)...)
+	if err := p.conn.WriteMessage(websocket.TextMessage, raw); err != nil {
+		return fmt.Errorf("sending event: %w", err)
+	}
 
-func (f eventPublisherFunc) PublishEvent(raw json.RawMessage) error {
-	return f(raw)
+	return nil
 }
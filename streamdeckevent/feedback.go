@@ -0,0 +1,206 @@
+package streamdeckevent
+
+// Layout identifies a touchscreen layout for an encoder. It is either one of the built-in layouts or the path to a
+// custom layout file relative to the plugin.
+type Layout string
+
+const (
+	// LayoutX1 displays a title and an icon.
+	LayoutX1 Layout = "$X1"
+	// LayoutA0 displays a title and a full-canvas pixmap.
+	LayoutA0 Layout = "$A0"
+	// LayoutA1 displays a title, an icon, and a value.
+	LayoutA1 Layout = "$A1"
+	// LayoutB1 displays a title, an icon, a value, and an indicator bar.
+	LayoutB1 Layout = "$B1"
+	// LayoutB2 displays a title, an icon, a value, and a gradient indicator bar.
+	LayoutB2 Layout = "$B2"
+	// LayoutC1 displays a title and two icons, each with an indicator bar.
+	LayoutC1 Layout = "$C1"
+)
+
+// TextAlignment is a horizontal text alignment.
+type TextAlignment string
+
+const (
+	AlignLeft   TextAlignment = "left"
+	AlignCenter TextAlignment = "center"
+	AlignRight  TextAlignment = "right"
+)
+
+// BarSubType is the style of a bar item.
+type BarSubType int
+
+const (
+	BarRectangle BarSubType = iota
+	BarDoubleRectangle
+	BarTrapezoid
+	BarDoubleTrapezoid
+	BarGroove
+)
+
+// FeedbackFont is the font of a text item.
+type FeedbackFont struct {
+	Size   int `json:"size,omitempty"`
+	Weight int `json:"weight,omitempty"`
+}
+
+// FeedbackRange is the range of values of a bar item.
+type FeedbackRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// FeedbackText updates a text item, such as a title or value.
+type FeedbackText struct {
+	Value      string        `json:"value,omitempty"`
+	Alignment  TextAlignment `json:"alignment,omitempty"`
+	Color      Color         `json:"color,omitempty"`
+	Font       *FeedbackFont `json:"font,omitempty"`
+	Background Color         `json:"background,omitempty"`
+	Opacity    *float64      `json:"opacity,omitempty"`
+	Enabled    *bool         `json:"enabled,omitempty"`
+}
+
+// FeedbackPixmap updates a pixmap item, such as an icon. Value is either a path to an image relative to the plugin
+// or a data URI.
+type FeedbackPixmap struct {
+	Value      string   `json:"value,omitempty"`
+	Background Color    `json:"background,omitempty"`
+	Opacity    *float64 `json:"opacity,omitempty"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// FeedbackBar updates a bar item, such as an indicator. For gradient bars, BarBackground holds the gradient, for
+// instance "0:#ff0000,1:#00ff00".
+type FeedbackBar struct {
+	Value         int            `json:"value"`
+	Range         *FeedbackRange `json:"range,omitempty"`
+	SubType       *BarSubType    `json:"subtype,omitempty"`
+	BarHeight     int            `json:"bar_h,omitempty"`
+	BorderWidth   *int           `json:"border_w,omitempty"`
+	BarBackground Color          `json:"bar_bg_c,omitempty"`
+	BarBorder     Color          `json:"bar_border_c,omitempty"`
+	BarFill       Color          `json:"bar_fill_c,omitempty"`
+	Opacity       *float64       `json:"opacity,omitempty"`
+	Enabled       *bool          `json:"enabled,omitempty"`
+}
+
+// X1Feedback updates the items of LayoutX1.
+type X1Feedback struct {
+	Title *FeedbackText
+	Icon  *FeedbackPixmap
+}
+
+// Payload builds the SetFeedbackPayload, omitting nil items.
+func (f X1Feedback) Payload() SetFeedbackPayload {
+	p := make(SetFeedbackPayload)
+	p.set("title", f.Title)
+	p.set("icon", f.Icon)
+	return p
+}
+
+// A0Feedback updates the items of LayoutA0.
+type A0Feedback struct {
+	Title  *FeedbackText
+	Canvas *FeedbackPixmap
+}
+
+// Payload builds the SetFeedbackPayload, omitting nil items.
+func (f A0Feedback) Payload() SetFeedbackPayload {
+	p := make(SetFeedbackPayload)
+	p.set("title", f.Title)
+	p.set("full-canvas", f.Canvas)
+	return p
+}
+
+// A1Feedback updates the items of LayoutA1.
+type A1Feedback struct {
+	Title *FeedbackText
+	Icon  *FeedbackPixmap
+	Value *FeedbackText
+}
+
+// Payload builds the SetFeedbackPayload, omitting nil items.
+func (f A1Feedback) Payload() SetFeedbackPayload {
+	p := make(SetFeedbackPayload)
+	p.set("title", f.Title)
+	p.set("icon", f.Icon)
+	p.set("value", f.Value)
+	return p
+}
+
+// B1Feedback updates the items of LayoutB1.
+type B1Feedback struct {
+	Title     *FeedbackText
+	Icon      *FeedbackPixmap
+	Value     *FeedbackText
+	Indicator *FeedbackBar
+}
+
+// Payload builds the SetFeedbackPayload, omitting nil items.
+func (f B1Feedback) Payload() SetFeedbackPayload {
+	p := make(SetFeedbackPayload)
+	p.set("title", f.Title)
+	p.set("icon", f.Icon)
+	p.set("value", f.Value)
+	p.set("indicator", f.Indicator)
+	return p
+}
+
+// B2Feedback updates the items of LayoutB2.
+type B2Feedback struct {
+	Title     *FeedbackText
+	Icon      *FeedbackPixmap
+	Value     *FeedbackText
+	Indicator *FeedbackBar
+}
+
+// Payload builds the SetFeedbackPayload, omitting nil items.
+func (f B2Feedback) Payload() SetFeedbackPayload {
+	p := make(SetFeedbackPayload)
+	p.set("title", f.Title)
+	p.set("icon", f.Icon)
+	p.set("value", f.Value)
+	p.set("indicator", f.Indicator)
+	return p
+}
+
+// C1Feedback updates the items of LayoutC1.
+type C1Feedback struct {
+	Title      *FeedbackText
+	Icon1      *FeedbackPixmap
+	Icon2      *FeedbackPixmap
+	Indicator1 *FeedbackBar
+	Indicator2 *FeedbackBar
+}
+
+// Payload builds the SetFeedbackPayload, omitting nil items.
+func (f C1Feedback) Payload() SetFeedbackPayload {
+	p := make(SetFeedbackPayload)
+	p.set("title", f.Title)
+	p.set("icon1", f.Icon1)
+	p.set("icon2", f.Icon2)
+	p.set("indicator1", f.Indicator1)
+	p.set("indicator2", f.Indicator2)
+	return p
+}
+
+func (p SetFeedbackPayload) set(key string, item interface{}) {
+	switch i := item.(type) {
+	case *FeedbackText:
+		if i == nil {
+			return
+		}
+	case *FeedbackPixmap:
+		if i == nil {
+			return
+		}
+	case *FeedbackBar:
+		if i == nil {
+			return
+		}
+	}
+
+	p[key] = item
+}
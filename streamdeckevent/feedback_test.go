@@ -0,0 +1,159 @@
+package streamdeckevent_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+func TestFeedbackPayload(t *testing.T) {
+	opacity := 0.5
+	subType := streamdeckevent.BarTrapezoid
+
+	testCases := []struct {
+		name    string
+		payload streamdeckevent.SetFeedbackPayload
+		want    string
+	}{
+		{
+			name: "X1",
+			payload: streamdeckevent.X1Feedback{
+				Title: &streamdeckevent.FeedbackText{Value: "Volume"},
+				Icon:  &streamdeckevent.FeedbackPixmap{Value: "images/volume"},
+			}.Payload(),
+			want: `{"title":{"value":"Volume"},"icon":{"value":"images/volume"}}`,
+		},
+		{
+			name: "A0",
+			payload: streamdeckevent.A0Feedback{
+				Canvas: &streamdeckevent.FeedbackPixmap{Value: "data:image/png;base64,iVBORw0KGgo=", Background: "#000000"},
+			}.Payload(),
+			want: `{"full-canvas":{"value":"data:image/png;base64,iVBORw0KGgo=","background":"#000000"}}`,
+		},
+		{
+			name: "A1",
+			payload: streamdeckevent.A1Feedback{
+				Title: &streamdeckevent.FeedbackText{Value: "Volume"},
+				Value: &streamdeckevent.FeedbackText{Value: "50%", Alignment: streamdeckevent.AlignRight, Font: &streamdeckevent.FeedbackFont{Size: 16, Weight: 600}},
+			}.Payload(),
+			want: `{"title":{"value":"Volume"},"value":{"value":"50%","alignment":"right","font":{"size":16,"weight":600}}}`,
+		},
+		{
+			name: "B1",
+			payload: streamdeckevent.B1Feedback{
+				Title:     &streamdeckevent.FeedbackText{Value: "Volume"},
+				Icon:      &streamdeckevent.FeedbackPixmap{Value: "images/volume", Opacity: &opacity},
+				Value:     &streamdeckevent.FeedbackText{Value: "50%", Color: "#ffffff"},
+				Indicator: &streamdeckevent.FeedbackBar{Value: 50, Range: &streamdeckevent.FeedbackRange{Min: 0, Max: 100}, SubType: &subType},
+			}.Payload(),
+			want: `{
+				"title": {"value": "Volume"},
+				"icon": {"value": "images/volume", "opacity": 0.5},
+				"value": {"value": "50%", "color": "#ffffff"},
+				"indicator": {"value": 50, "range": {"min": 0, "max": 100}, "subtype": 2}
+			}`,
+		},
+		{
+			name: "B2",
+			payload: streamdeckevent.B2Feedback{
+				Indicator: &streamdeckevent.FeedbackBar{Value: 0, BarBackground: "0:#ff0000,1:#00ff00", BarHeight: 12},
+			}.Payload(),
+			want: `{"indicator":{"value":0,"bar_h":12,"bar_bg_c":"0:#ff0000,1:#00ff00"}}`,
+		},
+		{
+			name: "C1",
+			payload: streamdeckevent.C1Feedback{
+				Title:      &streamdeckevent.FeedbackText{Value: "Mixer"},
+				Icon1:      &streamdeckevent.FeedbackPixmap{Value: "images/left"},
+				Icon2:      &streamdeckevent.FeedbackPixmap{Value: "images/right"},
+				Indicator1: &streamdeckevent.FeedbackBar{Value: 25},
+				Indicator2: &streamdeckevent.FeedbackBar{Value: 75, BarFill: "#00ff00", BarBorder: "#ffffff"},
+			}.Payload(),
+			want: `{
+				"title": {"value": "Mixer"},
+				"icon1": {"value": "images/left"},
+				"icon2": {"value": "images/right"},
+				"indicator1": {"value": 25},
+				"indicator2": {"value": 75, "bar_border_c": "#ffffff", "bar_fill_c": "#00ff00"}
+			}`,
+		},
+		{
+			name:    "empty",
+			payload: streamdeckevent.B1Feedback{}.Payload(),
+			want:    `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := json.Marshal(tc.payload)
+			if err != nil {
+				t.Fatalf("marshalling: %v", err)
+			}
+			assertJSON(t, string(raw), tc.want)
+		})
+	}
+}
+
+func TestSetFeedback(t *testing.T) {
+	// Items may also be given their value alone.
+	var event streamdeckevent.SetFeedback
+	roundTrip(t, `{
+		"event": "setFeedback",
+		"context": "opaqueValue",
+		"payload": {
+			"title": "Volume",
+			"value": "50%",
+			"indicator": {"value": 50, "bar_fill_c": "#ff0000"}
+		}
+	}`, &event)
+
+	if event.Event != streamdeckevent.SetFeedbackName || event.Payload["title"] != "Volume" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestSetFeedbackLayout(t *testing.T) {
+	var event streamdeckevent.SetFeedbackLayout
+	roundTrip(t, `{
+		"event": "setFeedbackLayout",
+		"context": "opaqueValue",
+		"payload": {"layout": "$B1"}
+	}`, &event)
+
+	if event.Event != streamdeckevent.SetFeedbackLayoutName || event.Payload.Layout != streamdeckevent.LayoutB1 {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	var custom streamdeckevent.SetFeedbackLayout
+	roundTrip(t, `{
+		"event": "setFeedbackLayout",
+		"context": "opaqueValue",
+		"payload": {"layout": "layouts/custom.json"}
+	}`, &custom)
+	if custom.Payload.Layout != "layouts/custom.json" {
+		t.Fatalf("unexpected event %+v", custom)
+	}
+}
+
+func TestSetTriggerDescription(t *testing.T) {
+	var event streamdeckevent.SetTriggerDescription
+	roundTrip(t, `{
+		"event": "setTriggerDescription",
+		"context": "opaqueValue",
+		"payload": {
+			"longTouch": "Reset",
+			"push": "Mute",
+			"rotate": "Adjust volume",
+			"touch": "Show mixer"
+		}
+	}`, &event)
+
+	if event.Event != streamdeckevent.SetTriggerDescriptionName || event.Payload.Rotate != "Adjust volume" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	// Descriptions left out are reset to their defaults by the application.
+	roundTrip(t, `{"event":"setTriggerDescription","context":"opaqueValue","payload":{"push":"Mute"}}`, &streamdeckevent.SetTriggerDescription{})
+}
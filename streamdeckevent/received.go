@@ -13,6 +13,10 @@ const (
 	DeviceDidDisconnectName           streamdeckcore.EventName = "deviceDidDisconnect"
 	DidReceiveGlobalSettingsName      streamdeckcore.EventName = "didReceiveGlobalSettings"
 	DidReceiveSettingsName            streamdeckcore.EventName = "didReceiveSettings"
+	DialDownName                      streamdeckcore.EventName = "dialDown"
+	DialPressName                     streamdeckcore.EventName = "dialPress"
+	DialRotateName                    streamdeckcore.EventName = "dialRotate"
+	DialUpName                        streamdeckcore.EventName = "dialUp"
 	KeyDownName                       streamdeckcore.EventName = "keyDown"
 	KeyUpName                         streamdeckcore.EventName = "keyUp"
 	PropertyInspectorDidAppearName    streamdeckcore.EventName = "propertyInspectorDidAppear"
@@ -20,6 +24,7 @@ const (
 	SendToPluginName                  streamdeckcore.EventName = "sendToPlugin"
 	SystemDidWakeUpName               streamdeckcore.EventName = "systemDidWakeUp"
 	TitleParametersDidChangeName      streamdeckcore.EventName = "titleParametersDidChange"
+	TouchTapName                      streamdeckcore.EventName = "touchTap"
 	WillAppearName                    streamdeckcore.EventName = "willAppear"
 	WillDisappearName                 streamdeckcore.EventName = "willDisappear"
 )
@@ -55,7 +60,7 @@ type DeviceDidDisconnect struct {
 
 type DidReceiveGlobalSettings struct {
 	Event   streamdeckcore.EventName        `json:"event"`
-	Payload DidReceiveGlobalSettingsPayload `json:"settings"`
+	Payload DidReceiveGlobalSettingsPayload `json:"payload"`
 }
 
 type DidReceiveGlobalSettingsPayload struct {
@@ -73,9 +78,72 @@ type DidReceiveSettings struct {
 type DidReceiveSettingsPayload struct {
 	Settings        json.RawMessage `json:"settings"`
 	Coordinates     Coordinates     `json:"coordinates"`
+	Controller      Controller      `json:"controller,omitempty"`
 	IsInMultiAction bool            `json:"isInMultiAction"`
 }
 
+type DialDown struct {
+	Action  streamdeckcore.ActionUUID   `json:"action"`
+	Event   streamdeckcore.EventName    `json:"event"`
+	Context streamdeckcore.EventContext `json:"context"`
+	Device  streamdeckcore.DeviceUUID   `json:"device"`
+	Payload DialDownPayload             `json:"payload"`
+}
+
+type DialDownPayload struct {
+	Settings    json.RawMessage `json:"settings"`
+	Coordinates Coordinates     `json:"coordinates"`
+	Controller  Controller      `json:"controller"`
+}
+
+// DialPress is sent by versions of the Stream Deck application prior to 6.1 when a dial is pressed or released.
+// Newer versions send DialDown and DialUp instead.
+type DialPress struct {
+	Action  streamdeckcore.ActionUUID   `json:"action"`
+	Event   streamdeckcore.EventName    `json:"event"`
+	Context streamdeckcore.EventContext `json:"context"`
+	Device  streamdeckcore.DeviceUUID   `json:"device"`
+	Payload DialPressPayload            `json:"payload"`
+}
+
+type DialPressPayload struct {
+	Settings    json.RawMessage `json:"settings"`
+	Coordinates Coordinates     `json:"coordinates"`
+	Controller  Controller      `json:"controller"`
+	Pressed     bool            `json:"pressed"`
+}
+
+type DialRotate struct {
+	Action  streamdeckcore.ActionUUID   `json:"action"`
+	Event   streamdeckcore.EventName    `json:"event"`
+	Context streamdeckcore.EventContext `json:"context"`
+	Device  streamdeckcore.DeviceUUID   `json:"device"`
+	Payload DialRotatePayload           `json:"payload"`
+}
+
+type DialRotatePayload struct {
+	Settings    json.RawMessage `json:"settings"`
+	Coordinates Coordinates     `json:"coordinates"`
+	Controller  Controller      `json:"controller"`
+	// Ticks is the number of ticks the dial was rotated; negative values are counter-clockwise.
+	Ticks   int  `json:"ticks"`
+	Pressed bool `json:"pressed"`
+}
+
+type DialUp struct {
+	Action  streamdeckcore.ActionUUID   `json:"action"`
+	Event   streamdeckcore.EventName    `json:"event"`
+	Context streamdeckcore.EventContext `json:"context"`
+	Device  streamdeckcore.DeviceUUID   `json:"device"`
+	Payload DialUpPayload               `json:"payload"`
+}
+
+type DialUpPayload struct {
+	Settings    json.RawMessage `json:"settings"`
+	Coordinates Coordinates     `json:"coordinates"`
+	Controller  Controller      `json:"controller"`
+}
+
 type KeyDown struct {
 	Action  streamdeckcore.ActionUUID   `json:"action"`
 	Event   streamdeckcore.EventName    `json:"event"`
@@ -142,10 +210,11 @@ type TitleParametersDidChange struct {
 }
 
 type TitleParametersDidChangePayload struct {
-	Coordinates Coordinates     `json:"coordinates"`
-	Settings    json.RawMessage `json:"settings"`
-	State       int             `json:"state"`
-	Title       string          `json:"title"`
+	Coordinates     Coordinates     `json:"coordinates"`
+	Settings        json.RawMessage `json:"settings"`
+	State           int             `json:"state"`
+	Title           string          `json:"title"`
+	TitleParameters TitleParameters `json:"titleParameters"`
 }
 
 type TitleParameters struct {
@@ -158,6 +227,23 @@ type TitleParameters struct {
 	TitleColor     Color             `json:"titleColor"`
 }
 
+type TouchTap struct {
+	Action  streamdeckcore.ActionUUID   `json:"action"`
+	Event   streamdeckcore.EventName    `json:"event"`
+	Context streamdeckcore.EventContext `json:"context"`
+	Device  streamdeckcore.DeviceUUID   `json:"device"`
+	Payload TouchTapPayload             `json:"payload"`
+}
+
+type TouchTapPayload struct {
+	Settings    json.RawMessage `json:"settings"`
+	Coordinates Coordinates     `json:"coordinates"`
+	Controller  Controller      `json:"controller"`
+	// TapPos is the x and y position of the tap on the touchscreen, relative to the action's segment.
+	TapPos [2]int `json:"tapPos"`
+	Hold   bool   `json:"hold"`
+}
+
 type WillAppear struct {
 	Action  streamdeckcore.ActionUUID   `json:"action"`
 	Event   streamdeckcore.EventName    `json:"event"`
@@ -169,6 +255,7 @@ type WillAppear struct {
 type WillAppearPayload struct {
 	Settings        json.RawMessage `json:"settings"`
 	Coordinates     Coordinates     `json:"coordinates"`
+	Controller      Controller      `json:"controller,omitempty"`
 	State           int             `json:"state"`
 	IsInMultiAction bool            `json:"isInMultiAction"`
 }
@@ -184,6 +271,7 @@ type WillDisappear struct {
 type WillDisappearPayload struct {
 	Settings        json.RawMessage `json:"settings"`
 	Coordinates     Coordinates     `json:"coordinates"`
+	Controller      Controller      `json:"controller,omitempty"`
 	State           int             `json:"state"`
 	IsInMultiAction bool            `json:"isInMultiAction"`
 }
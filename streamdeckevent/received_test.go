@@ -0,0 +1,140 @@
+package streamdeckevent_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// roundTrip unmarshals the raw event into the value event points to and checks that marshalling it again gives back
+// the same JSON.
+func roundTrip(t *testing.T, raw string, event interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal([]byte(raw), event); err != nil {
+		t.Fatalf("unmarshalling: %v", err)
+	}
+
+	marshalled, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshalling: %v", err)
+	}
+	assertJSON(t, string(marshalled), raw)
+}
+
+func assertJSON(t *testing.T, got, want string) {
+	t.Helper()
+
+	var g, w interface{}
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		t.Fatalf("unmarshalling %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("unmarshalling %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDialDown(t *testing.T) {
+	var event streamdeckevent.DialDown
+	roundTrip(t, `{
+		"action": "com.elgato.example.action1",
+		"event": "dialDown",
+		"context": "opaqueValue",
+		"device": "opaqueValue",
+		"payload": {
+			"controller": "Encoder",
+			"settings": {"volume": 50},
+			"coordinates": {"column": 3, "row": 1}
+		}
+	}`, &event)
+
+	if event.Event != streamdeckevent.DialDownName || event.Payload.Controller != streamdeckevent.Encoder || event.Payload.Coordinates.Column != 3 {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestDialUp(t *testing.T) {
+	var event streamdeckevent.DialUp
+	roundTrip(t, `{
+		"action": "com.elgato.example.action1",
+		"event": "dialUp",
+		"context": "opaqueValue",
+		"device": "opaqueValue",
+		"payload": {
+			"controller": "Encoder",
+			"settings": {},
+			"coordinates": {"column": 3, "row": 1}
+		}
+	}`, &event)
+
+	if event.Event != streamdeckevent.DialUpName || event.Payload.Coordinates.Row != 1 {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestDialPress(t *testing.T) {
+	var event streamdeckevent.DialPress
+	roundTrip(t, `{
+		"action": "com.elgato.example.action1",
+		"event": "dialPress",
+		"context": "opaqueValue",
+		"device": "opaqueValue",
+		"payload": {
+			"controller": "Encoder",
+			"settings": {},
+			"coordinates": {"column": 3, "row": 1},
+			"pressed": true
+		}
+	}`, &event)
+
+	if event.Event != streamdeckevent.DialPressName || !event.Payload.Pressed {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestDialRotate(t *testing.T) {
+	var event streamdeckevent.DialRotate
+	roundTrip(t, `{
+		"action": "com.elgato.example.action1",
+		"event": "dialRotate",
+		"context": "opaqueValue",
+		"device": "opaqueValue",
+		"payload": {
+			"controller": "Encoder",
+			"settings": {},
+			"coordinates": {"column": 3, "row": 1},
+			"ticks": -2,
+			"pressed": false
+		}
+	}`, &event)
+
+	if event.Event != streamdeckevent.DialRotateName || event.Payload.Ticks != -2 || event.Payload.Pressed {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestTouchTap(t *testing.T) {
+	var event streamdeckevent.TouchTap
+	roundTrip(t, `{
+		"action": "com.elgato.example.action1",
+		"event": "touchTap",
+		"context": "opaqueValue",
+		"device": "opaqueValue",
+		"payload": {
+			"controller": "Encoder",
+			"settings": {},
+			"coordinates": {"column": 3, "row": 1},
+			"tapPos": [76, 40],
+			"hold": true
+		}
+	}`, &event)
+
+	if event.Event != streamdeckevent.TouchTapName || event.Payload.TapPos != [2]int{76, 40} || !event.Payload.Hold {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
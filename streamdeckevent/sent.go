@@ -12,11 +12,14 @@ const (
 	LogMessageName              streamdeckcore.EventName = "logMessage"
 	OpenURLName                 streamdeckcore.EventName = "openUrl"
 	SendToPropertyInspectorName streamdeckcore.EventName = "sendToPropertyInspector"
+	SetFeedbackName             streamdeckcore.EventName = "setFeedback"
+	SetFeedbackLayoutName       streamdeckcore.EventName = "setFeedbackLayout"
 	SetGlobalSettingsName       streamdeckcore.EventName = "setGlobalSettings"
 	SetSettingsName             streamdeckcore.EventName = "setSettings"
 	SetImageName                streamdeckcore.EventName = "setImage"
 	SetStateName                streamdeckcore.EventName = "setState"
 	SetTitleName                streamdeckcore.EventName = "setTitle"
+	SetTriggerDescriptionName   streamdeckcore.EventName = "setTriggerDescription"
 	ShowAlertName               streamdeckcore.EventName = "showAlert"
 	ShowOKName                  streamdeckcore.EventName = "showOk"
 	SwitchToProfileName         streamdeckcore.EventName = "switchToProfile"
@@ -57,6 +60,27 @@ type SendToPropertyInspector struct {
 	Payload json.RawMessage             `json:"payload"`
 }
 
+type SetFeedback struct {
+	Event   streamdeckcore.EventName    `json:"event"`
+	Context streamdeckcore.EventContext `json:"context"`
+	Payload SetFeedbackPayload          `json:"payload"`
+}
+
+// SetFeedbackPayload maps the keys of items in the current touchscreen layout to their new values. A value is either
+// one of the Feedback item types or a string, number, or boolean shorthand for the item's value. The Payload methods
+// of the built-in layout types, such as B1Feedback, build a SetFeedbackPayload.
+type SetFeedbackPayload map[string]interface{}
+
+type SetFeedbackLayout struct {
+	Event   streamdeckcore.EventName    `json:"event"`
+	Context streamdeckcore.EventContext `json:"context"`
+	Payload SetFeedbackLayoutPayload    `json:"payload"`
+}
+
+type SetFeedbackLayoutPayload struct {
+	Layout Layout `json:"layout"`
+}
+
 type SetGlobalSettings struct {
 	Event   streamdeckcore.EventName  `json:"event"`
 	Context streamdeckcore.PluginUUID `json:"context"`
@@ -103,6 +127,19 @@ type SetTitlePayload struct {
 	State  *int   `json:"state,omitempty"`
 }
 
+type SetTriggerDescription struct {
+	Event   streamdeckcore.EventName     `json:"event"`
+	Context streamdeckcore.EventContext  `json:"context"`
+	Payload SetTriggerDescriptionPayload `json:"payload"`
+}
+
+type SetTriggerDescriptionPayload struct {
+	LongTouch string `json:"longTouch,omitempty"`
+	Push      string `json:"push,omitempty"`
+	Rotate    string `json:"rotate,omitempty"`
+	Touch     string `json:"touch,omitempty"`
+}
+
 type ShowAlert struct {
 	Event   streamdeckcore.EventName    `json:"event"`
 	Context streamdeckcore.EventContext `json:"context"`
@@ -10,34 +10,40 @@ type Base64String string
 // Color is a color.
 type Color string
 
+// Controller is the kind of control an action instance is attached to.
+type Controller string
+
+const (
+	Encoder Controller = "Encoder"
+	Keypad  Controller = "Keypad"
+)
+
 // Coordinates is the column and row of a button.
 type Coordinates struct {
 	Column int `json:"column"`
 	Row    int `json:"row"`
 }
 
-// DeviceInfo is the device information provided by the device.
-type DeviceInfo struct {
-	Type       DeviceType                `json:"type,omitempty"`
-	Size       DeviceSize                `json:"size,omitempty"`
-	DeviceName streamdeckcore.DeviceName `json:"deviceName,omitempty"`
-}
+// DeviceInfo is the device information provided by the device. It is an alias for streamdeckcore.DeviceInfo.
+type DeviceInfo = streamdeckcore.DeviceInfo
 
-// DeviceSize is the size of a Streamdeck.
-type DeviceSize struct {
-	Columns int `json:"columns,omitempty"`
-	Rows    int `json:"rows,omitempty"`
-}
+// DeviceSize is the size of a Streamdeck. It is an alias for streamdeckcore.DeviceSize.
+type DeviceSize = streamdeckcore.DeviceSize
 
-// DeviceType is the type of device.
-type DeviceType int
+// DeviceType is the type of device. It is an alias for streamdeckcore.DeviceType.
+type DeviceType = streamdeckcore.DeviceType
 
 const (
-	StreamDeck       DeviceType = 0
-	StreamDeckMini   DeviceType = 1
-	StreamDeckXL     DeviceType = 2
-	StreamDeckMobile DeviceType = 3
-	CorsairGKeys     DeviceType = 4
+	StreamDeck       = streamdeckcore.StreamDeck
+	StreamDeckMini   = streamdeckcore.StreamDeckMini
+	StreamDeckXL     = streamdeckcore.StreamDeckXL
+	StreamDeckMobile = streamdeckcore.StreamDeckMobile
+	CorsairGKeys     = streamdeckcore.CorsairGKeys
+	StreamDeckPedal  = streamdeckcore.StreamDeckPedal
+	CorsairVoyager   = streamdeckcore.CorsairVoyager
+	StreamDeckPlus   = streamdeckcore.StreamDeckPlus
+	SCUFController   = streamdeckcore.SCUFController
+	StreamDeckNeo    = streamdeckcore.StreamDeckNeo
 )
 
 // Target indicates where to apply an event.
@@ -0,0 +1,92 @@
+package streamdeckimage
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+)
+
+// EncodeFrames fits each image to the key size and encodes it as a frame shown for the duration.
+func EncodeFrames(images []image.Image, duration time.Duration, opts Options) ([]streamdeck.Frame, error) {
+	frames := make([]streamdeck.Frame, 0, len(images))
+	for i, img := range images {
+		uri, err := DataURI(img, opts)
+		if err != nil {
+			return nil, fmt.Errorf("encoding frame %d: %w", i, err)
+		}
+
+		frames = append(frames, streamdeck.Frame{
+			Image:    uri,
+			Duration: duration,
+		})
+	}
+
+	return frames, nil
+}
+
+// DecodeGIF decodes an animated GIF into an Animation, composing each frame with those before it according to its
+// disposal method and fitting it to the key size. The animation loops as many times as the GIF's loop count says,
+// forever when it is 0.
+func DecodeGIF(r io.Reader, opts Options) (streamdeck.Animation, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return streamdeck.Animation{}, fmt.Errorf("decoding gif: %w", err)
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() && len(g.Image) > 0 {
+		bounds = g.Image[0].Bounds()
+	}
+
+	canvas := image.NewRGBA(bounds)
+	frames := make([]streamdeck.Frame, 0, len(g.Image))
+	for i, paletted := range g.Image {
+		var previous *image.RGBA
+		disposal := byte(gif.DisposalNone)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			draw.Draw(previous, bounds, canvas, bounds.Min, draw.Src)
+		}
+
+		draw.Draw(canvas, paletted.Bounds(), paletted, paletted.Bounds().Min, draw.Over)
+
+		uri, err := DataURI(canvas, opts)
+		if err != nil {
+			return streamdeck.Animation{}, fmt.Errorf("encoding frame %d: %w", i, err)
+		}
+
+		frames = append(frames, streamdeck.Frame{
+			Image:    uri,
+			Duration: time.Duration(g.Delay[i]) * 10 * time.Millisecond,
+		})
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, paletted.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			draw.Draw(canvas, bounds, previous, bounds.Min, draw.Src)
+		}
+	}
+
+	// A positive loop count is the number of times the GIF repeats after playing once.
+	plays := 0
+	if g.LoopCount > 0 {
+		plays = g.LoopCount + 1
+	}
+
+	return streamdeck.Animation{
+		Frames: frames,
+		Loop:   g.LoopCount >= 0,
+		Plays:  plays,
+		Target: opts.Target,
+		State:  opts.State,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package streamdeckimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckimage"
+)
+
+func TestDecodeGIFLoopCount(t *testing.T) {
+	tests := []struct {
+		loopCount int
+		loop      bool
+		plays     int
+	}{
+		{loopCount: -1, loop: false, plays: 0},
+		{loopCount: 0, loop: true, plays: 0},
+		{loopCount: 2, loop: true, plays: 3},
+	}
+
+	for _, test := range tests {
+		palette := color.Palette{color.Black, color.White}
+		g := &gif.GIF{
+			Image:     []*image.Paletted{image.NewPaletted(image.Rect(0, 0, 8, 8), palette), image.NewPaletted(image.Rect(0, 0, 8, 8), palette)},
+			Delay:     []int{10, 10},
+			LoopCount: test.loopCount,
+		}
+
+		var buf bytes.Buffer
+		if err := gif.EncodeAll(&buf, g); err != nil {
+			t.Fatalf("encoding gif: %v", err)
+		}
+
+		animation, err := streamdeckimage.DecodeGIF(&buf, streamdeckimage.Options{})
+		if err != nil {
+			t.Fatalf("decoding gif: %v", err)
+		}
+		if animation.Loop != test.loop || animation.Plays != test.plays {
+			t.Errorf("loop count %d: expected Loop %t and Plays %d, got %t and %d", test.loopCount, test.loop, test.plays, animation.Loop, animation.Plays)
+		}
+	}
+}
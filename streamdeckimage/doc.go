@@ -0,0 +1,9 @@
+// Package streamdeckimage prepares images for keys, scaling them to the key size of a device and encoding them as the
+// data URIs expected by streamdeckevent.SetImagePayload.
+//
+//	img := image.NewRGBA(image.Rect(0, 0, 144, 144))
+//	// draw the key face...
+//	err := streamdeckimage.SetImage(publisher, img, streamdeckimage.Options{
+//		Size: streamdeckimage.KeySizeFor(info, device.Type),
+//	})
+package streamdeckimage
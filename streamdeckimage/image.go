@@ -0,0 +1,188 @@
+package streamdeckimage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// Register the GIF decoder so files in any of the formats supported by the application can be loaded.
+	_ "image/gif"
+
+	xdraw "golang.org/x/image/draw"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+const (
+	// DefaultKeySize is the size, in pixels, of a key image on most devices.
+	DefaultKeySize = 72
+	// HighResolutionKeySize is the size, in pixels, of a key image on devices with high resolution keys.
+	HighResolutionKeySize = 144
+)
+
+// KeySize returns the size, in pixels, of a key image for the device type at a pixel ratio of 1. Use KeySizeFor to
+// account for the pixel ratio of the display the application runs on.
+func KeySize(deviceType streamdeckcore.DeviceType) int {
+	switch deviceType {
+	case streamdeckcore.StreamDeckXL, streamdeckcore.StreamDeckPlus:
+		return HighResolutionKeySize
+	default:
+		return DefaultKeySize
+	}
+}
+
+// KeySizeFor returns the size, in pixels, of a key image for the device type, multiplied by the DevicePixelRatio the
+// application registered the plugin with. A DevicePixelRatio of 0 is treated as 1.
+func KeySizeFor(info streamdeckcore.RegistrationInfo, deviceType streamdeckcore.DeviceType) int {
+	ratio := info.DevicePixelRatio
+	if ratio < 1 {
+		ratio = 1
+	}
+
+	return KeySize(deviceType) * ratio
+}
+
+// Format is the encoding of a key image.
+type Format int
+
+const (
+	PNG Format = iota
+	JPEG
+)
+
+// Options configures how an image is prepared for a key and published.
+type Options struct {
+	// Size is the width and height, in pixels, the image is fit within. A Size of 0 uses DefaultKeySize.
+	Size int
+	// Format is the encoding of the image.
+	Format Format
+	// Quality is the JPEG quality, ranging from 1 to 100. A Quality of 0 uses jpeg.DefaultQuality.
+	Quality int
+	// Target indicates whether the image is displayed on the hardware, the software, or both.
+	Target streamdeckevent.Target
+	// State is the state of the action to set the image for, or nil for all states.
+	State *int
+}
+
+// Fit scales the image to fit within a square of size pixels, preserving its aspect ratio and centering it over a
+// transparent background. An image already of that size is returned as is.
+func Fit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() == size && bounds.Dy() == size {
+		return img
+	}
+
+	w, h := size, size
+	if bounds.Dx() > bounds.Dy() {
+		h = bounds.Dy() * size / bounds.Dx()
+	} else if bounds.Dy() > bounds.Dx() {
+		w = bounds.Dx() * size / bounds.Dy()
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	r := image.Rect(0, 0, w, h).Add(image.Pt((size-w)/2, (size-h)/2))
+	xdraw.CatmullRom.Scale(dst, r, img, bounds, draw.Over, nil)
+	return dst
+}
+
+// DataURI fits the image to the key size and encodes it as a data URI.
+func DataURI(img image.Image, opts Options) (streamdeckevent.Base64String, error) {
+	size := opts.Size
+	if size <= 0 {
+		size = DefaultKeySize
+	}
+
+	img = Fit(img, size)
+
+	var buf bytes.Buffer
+	var mediaType string
+	switch opts.Format {
+	case PNG:
+		mediaType = "image/png"
+		if err := png.Encode(&buf, img); err != nil {
+			return "", fmt.Errorf("encoding png: %w", err)
+		}
+	case JPEG:
+		mediaType = "image/jpeg"
+		quality := opts.Quality
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return "", fmt.Errorf("encoding jpeg: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("unknown image format %d", opts.Format)
+	}
+
+	return streamdeckevent.Base64String("data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
+
+// SVGDataURI encodes the SVG document as a data URI. The application renders SVG images itself, so they are neither
+// scaled nor rasterized.
+func SVGDataURI(svg string) streamdeckevent.Base64String {
+	return streamdeckevent.Base64String("data:image/svg+xml;charset=utf8," + url.PathEscape(svg))
+}
+
+// FileDataURI loads the image file and encodes it as a data URI. SVG files are encoded with SVGDataURI, while other
+// files are decoded, fit to the key size, and encoded according to the options.
+func FileDataURI(path string, opts Options) (streamdeckevent.Base64String, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading image: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		return SVGDataURI(string(data)), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("decoding image %s: %w", path, err)
+	}
+
+	return DataURI(img, opts)
+}
+
+// SetImage fits the image to the key size, encodes it, and publishes it for the action instance.
+func SetImage(publisher streamdeck.ActionInstancePublisher, img image.Image, opts Options) error {
+	uri, err := DataURI(img, opts)
+	if err != nil {
+		return err
+	}
+
+	return publish(publisher, uri, opts)
+}
+
+// SetSVG publishes the SVG document for the action instance.
+func SetSVG(publisher streamdeck.ActionInstancePublisher, svg string, opts Options) error {
+	return publish(publisher, SVGDataURI(svg), opts)
+}
+
+// SetImageFile loads the image file and publishes it for the action instance.
+func SetImageFile(publisher streamdeck.ActionInstancePublisher, path string, opts Options) error {
+	uri, err := FileDataURI(path, opts)
+	if err != nil {
+		return err
+	}
+
+	return publish(publisher, uri, opts)
+}
+
+func publish(publisher streamdeck.ActionInstancePublisher, uri streamdeckevent.Base64String, opts Options) error {
+	return publisher.SetImage(streamdeckevent.SetImagePayload{
+		Image:  uri,
+		Target: opts.Target,
+		State:  opts.State,
+	})
+}
@@ -0,0 +1,61 @@
+package streamdeckinspector
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ScriptName is the name of the JavaScript helper talking to a Router from the property inspector.
+const ScriptName = "streamdeck-rpc.js"
+
+//go:embed assets
+var embedded embed.FS
+
+// Assets holds the files shipped for property inspectors, such as the script named ScriptName.
+var Assets fs.FS = mustSub(embedded, "assets")
+
+// WriteAssets writes the files of Assets into dir, for instance the directory of a property inspector before
+// packaging the plugin.
+func WriteAssets(dir string) error {
+	return writeFS(Assets, dir)
+}
+
+// writeFS copies the files of fsys into dir.
+func writeFS(fsys fs.FS, dir string) error {
+	return fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if d.IsDir() {
+			if err = os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("creating %s: %w", target, err)
+			}
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		if err = os.WriteFile(target, data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", target, err)
+		}
+
+		return nil
+	})
+}
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
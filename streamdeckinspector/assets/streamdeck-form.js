@@ -0,0 +1,55 @@
+// streamdeck-form.js binds the form generated by streamdeckinspector.Form to the settings of the action instance,
+// persisting changes with setSettings and refreshing the form whenever the settings change.
+(function () {
+  "use strict";
+
+  var form = document.getElementById("settings");
+
+  function read(input) {
+    switch (input.dataset.kind) {
+      case "bool":
+        return input.checked;
+      case "int":
+        return input.value === "" ? 0 : parseInt(input.value, 10);
+      case "float":
+        return input.value === "" ? 0 : parseFloat(input.value);
+      default:
+        return input.value;
+    }
+  }
+
+  function fill(settings) {
+    Array.prototype.forEach.call(form.elements, function (input) {
+      if (!input.name || !(input.name in settings) || input === document.activeElement) {
+        return;
+      }
+
+      if (input.dataset.kind === "bool") {
+        input.checked = !!settings[input.name];
+      } else {
+        input.value = settings[input.name];
+      }
+    });
+  }
+
+  StreamDeckRPC.connect(function (rpc) {
+    fill(rpc.settings);
+    rpc.onSettings(fill);
+
+    form.addEventListener("change", function (e) {
+      var input = e.target;
+      if (!input.name) {
+        return;
+      }
+
+      var value = read(input);
+      if (typeof value === "number" && isNaN(value)) {
+        return;
+      }
+
+      var settings = Object.assign({}, rpc.settings);
+      settings[input.name] = value;
+      rpc.setSettings(settings);
+    });
+  });
+})();
@@ -0,0 +1,148 @@
+// streamdeck-rpc.js connects a property inspector to its plugin, calling the handlers registered with
+// streamdeckinspector.On and receiving the notifications sent with Router.Notify.
+//
+//	<script src="streamdeck-rpc.js"></script>
+//	<script>
+//	  StreamDeckRPC.connect(function (rpc) {
+//	    rpc.call("listDevices", {}).then(function (devices) { ... }, function (err) { ... });
+//	    rpc.on("countChanged", function (count) { ... });
+//	  });
+//	</script>
+(function (global) {
+  "use strict";
+
+  function StreamDeckRPC(port, uuid, registerEvent, info, actionInfo) {
+    this.uuid = uuid;
+    this.info = typeof info === "string" ? JSON.parse(info) : info;
+    this.actionInfo = typeof actionInfo === "string" ? JSON.parse(actionInfo) : actionInfo;
+    this.settings = (this.actionInfo && this.actionInfo.payload && this.actionInfo.payload.settings) || {};
+
+    this._nextID = 1;
+    this._pending = {};
+    this._listeners = {};
+    this._settingsListeners = [];
+    this._queue = [];
+    this._open = false;
+
+    var self = this;
+    this._socket = new WebSocket("ws://127.0.0.1:" + port);
+    this._socket.onopen = function () {
+      self._socket.send(JSON.stringify({ event: registerEvent, uuid: uuid }));
+      self._open = true;
+      self._queue.forEach(function (data) {
+        self._socket.send(data);
+      });
+      self._queue = [];
+    };
+    this._socket.onmessage = function (e) {
+      self._receive(JSON.parse(e.data));
+    };
+    this._socket.onclose = function () {
+      self._open = false;
+      Object.keys(self._pending).forEach(function (id) {
+        self._pending[id].reject(new StreamDeckRPC.Error("connection closed"));
+      });
+      self._pending = {};
+    };
+  }
+
+  // Error is the error a call is rejected with, carrying the code and data replied by the plugin.
+  StreamDeckRPC.Error = function (message, code, data) {
+    this.name = "StreamDeckRPCError";
+    this.message = message;
+    this.code = code;
+    this.data = data;
+  };
+  StreamDeckRPC.Error.prototype = Object.create(Error.prototype);
+  StreamDeckRPC.Error.prototype.constructor = StreamDeckRPC.Error;
+
+  // connect defines the connectElgatoStreamDeckSocket function the application calls once the property inspector has
+  // loaded, and passes the connected StreamDeckRPC to callback.
+  StreamDeckRPC.connect = function (callback) {
+    global.connectElgatoStreamDeckSocket = function (port, uuid, registerEvent, info, actionInfo) {
+      callback(new StreamDeckRPC(port, uuid, registerEvent, info, actionInfo));
+    };
+  };
+
+  // call sends a request to the plugin, returning a Promise of its result.
+  StreamDeckRPC.prototype.call = function (method, params) {
+    var self = this;
+    var id = this._nextID++;
+    return new Promise(function (resolve, reject) {
+      self._pending[id] = { resolve: resolve, reject: reject };
+      self._sendToPlugin({ id: id, method: method, params: params });
+    });
+  };
+
+  // notify sends a notification to the plugin, which doesn't reply.
+  StreamDeckRPC.prototype.notify = function (method, params) {
+    this._sendToPlugin({ method: method, params: params });
+  };
+
+  // on registers the listener of the notifications the plugin sends for the method.
+  StreamDeckRPC.prototype.on = function (method, listener) {
+    this._listeners[method] = listener;
+  };
+
+  // setSettings persists the settings of the action instance. The plugin receives them as a didReceiveSettings event.
+  StreamDeckRPC.prototype.setSettings = function (settings) {
+    this.settings = settings;
+    this._send({ event: "setSettings", context: this.uuid, payload: settings });
+  };
+
+  // onSettings registers a listener called with the settings whenever they change.
+  StreamDeckRPC.prototype.onSettings = function (listener) {
+    this._settingsListeners.push(listener);
+  };
+
+  StreamDeckRPC.prototype._sendToPlugin = function (payload) {
+    this._send({
+      action: this.actionInfo && this.actionInfo.action,
+      event: "sendToPlugin",
+      context: this.uuid,
+      payload: payload,
+    });
+  };
+
+  StreamDeckRPC.prototype._send = function (event) {
+    var data = JSON.stringify(event);
+    if (this._open) {
+      this._socket.send(data);
+    } else {
+      this._queue.push(data);
+    }
+  };
+
+  StreamDeckRPC.prototype._receive = function (event) {
+    var self = this;
+    if (event.event === "didReceiveSettings") {
+      this.settings = (event.payload && event.payload.settings) || {};
+      this._settingsListeners.forEach(function (listener) {
+        listener(self.settings);
+      });
+      return;
+    }
+
+    if (event.event !== "sendToPropertyInspector" || !event.payload) {
+      return;
+    }
+
+    var msg = event.payload;
+    if (msg.id !== undefined && this._pending[msg.id]) {
+      var pending = this._pending[msg.id];
+      delete this._pending[msg.id];
+      if (msg.error) {
+        pending.reject(new StreamDeckRPC.Error(msg.error.message, msg.error.code, msg.error.data));
+      } else {
+        pending.resolve(msg.result);
+      }
+      return;
+    }
+
+    if (msg.method && this._listeners[msg.method]) {
+      this._listeners[msg.method](msg.params);
+    }
+  };
+
+  global.StreamDeckRPC = StreamDeckRPC;
+})(window);
@@ -0,0 +1,39 @@
+// Package streamdeckinspector structures the messages exchanged by an action instance and its property inspector
+// through the streamdeckevent.SendToPlugin and streamdeckevent.SendToPropertyInspector events.
+//
+// On the plugin side, a Router dispatches requests to typed handlers and replies with their results:
+//
+//	router := streamdeckinspector.NewRouter(publisher)
+//	streamdeckinspector.On(router, "listDevices", func(ctx context.Context, req ListDevicesRequest) ([]Device, error) {
+//		return devices(req.Type), nil
+//	})
+//
+//	type ActionInstance struct {
+//		*streamdeckinspector.Router
+//		...
+//	}
+//
+// On the property inspector side, the streamdeck-rpc.js helper in Assets assigns request IDs and resolves each call
+// with its reply:
+//
+//	StreamDeckRPC.connect(function (rpc) {
+//	  rpc.call("listDevices", { type: "camera" }).then(showDevices, showError);
+//	});
+//
+// An Inspector holds the files of a property inspector and is written alongside the manifest when it is generated.
+// Form generates one from the settings of an action:
+//
+//	streamdeck.WithManifest(streamdeckmanifest.Action{
+//		PropertyInspectorPath: "inspectors/counter/" + streamdeckinspector.FormPage,
+//		PropertyInspector:     streamdeckinspector.MustForm[Settings](),
+//		...
+//	})
+//
+// while New holds hand-written files, typically embedded, with PropertyInspectorPath naming their page:
+//
+//	//go:embed inspector
+//	var files embed.FS
+//
+//	sub, _ := fs.Sub(files, "inspector")
+//	inspector := streamdeckinspector.New(sub)
+package streamdeckinspector
@@ -0,0 +1,202 @@
+package streamdeckinspector
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+)
+
+// FormPage is the page of an Inspector made by Form, to use as the streamdeckmanifest.Action.PropertyInspectorPath
+// within the directory of the inspector.
+const FormPage = "index.html"
+
+//go:embed form.html
+var formHTML string
+
+var formTemplate = template.Must(template.New("form").Parse(formHTML))
+
+// Input types of the form fields.
+const (
+	InputText     = "text"
+	InputNumber   = "number"
+	InputCheckbox = "checkbox"
+	InputSelect   = "select"
+	InputColor    = "color"
+)
+
+// formField is an input of a generated form.
+type formField struct {
+	Name    string
+	Label   string
+	Type    string
+	Kind    string
+	Min     string
+	Max     string
+	Step    string
+	Options []formOption
+}
+
+type formOption struct {
+	Value string
+	Label string
+}
+
+// Form makes an Inspector whose page, FormPage, is a form editing the settings of type T, which must be a struct.
+// Changes are persisted with the streamdeckevent.SetSettings event as they are made, reaching the plugin as a
+// streamdeckevent.DidReceiveSettings event, and the form is refreshed whenever the settings change. Settings the
+// form doesn't cover are preserved.
+//
+// Each exported field is an input named after its JSON key, described by its `inspector` tag: a label followed by
+// comma-separated options. The type option is one of InputText, InputNumber, InputCheckbox, InputSelect, or
+// InputColor, and otherwise follows the kind of the field. Numbers accept min, max, and step, and selects take their
+// choices from options, separated by "|", each a value optionally followed by ":" and a label. Fields tagged "-" are
+// skipped.
+//
+//	type Settings struct {
+//		Name  string `json:"name" inspector:"Name"`
+//		Step  int    `json:"step" inspector:"Step,min=1,max=10"`
+//		Mode  string `json:"mode" inspector:"Mode,type=select,options=up:Up|down:Down"`
+//		Color string `json:"color" inspector:"Color,type=color"`
+//	}
+func Form[T any]() (*Inspector, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("settings type %s is not a struct", t)
+	}
+
+	var fields []formField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		field, ok, err := parseField(sf)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", sf.Name, t, err)
+		}
+		if ok {
+			fields = append(fields, field)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := formTemplate.Execute(&buf, struct {
+		Title  string
+		Fields []formField
+	}{
+		Title:  t.Name(),
+		Fields: fields,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating form for %s: %w", t, err)
+	}
+
+	i := New(nil)
+	i.generated[FormPage] = buf.Bytes()
+	return i, nil
+}
+
+// MustForm is like Form but panics on error, for initializing package variables and manifest entries.
+func MustForm[T any]() *Inspector {
+	i, err := Form[T]()
+	if err != nil {
+		panic(err)
+	}
+
+	return i
+}
+
+// parseField describes the input of a struct field, returning false when the field is skipped.
+func parseField(sf reflect.StructField) (formField, bool, error) {
+	tag, hasTag := sf.Tag.Lookup("inspector")
+	if tag == "-" {
+		return formField{}, false, nil
+	}
+
+	field := formField{
+		Name:  sf.Name,
+		Label: sf.Name,
+	}
+
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name == "-" {
+		return formField{}, false, nil
+	} else if name != "" {
+		field.Name = name
+	}
+
+	switch sf.Type.Kind() {
+	case reflect.String:
+		field.Kind, field.Type = "string", InputText
+	case reflect.Bool:
+		field.Kind, field.Type = "bool", InputCheckbox
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.Kind, field.Type = "int", InputNumber
+	case reflect.Float32, reflect.Float64:
+		field.Kind, field.Type = "float", InputNumber
+	default:
+		if !hasTag {
+			return formField{}, false, nil
+		}
+		return formField{}, false, fmt.Errorf("unsupported kind %s", sf.Type.Kind())
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		field.Label = parts[0]
+	}
+
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "type":
+			field.Type = value
+		case "min":
+			field.Min = value
+		case "max":
+			field.Max = value
+		case "step":
+			field.Step = value
+		case "options":
+			for _, option := range strings.Split(value, "|") {
+				v, label, ok := strings.Cut(option, ":")
+				if !ok {
+					label = v
+				}
+				field.Options = append(field.Options, formOption{Value: v, Label: label})
+			}
+		default:
+			return formField{}, false, fmt.Errorf("unknown option %q", key)
+		}
+	}
+
+	switch {
+	case field.Type == InputText || field.Type == InputColor:
+		if field.Kind != "string" {
+			return formField{}, false, fmt.Errorf("%s input requires a string", field.Type)
+		}
+	case field.Type == InputNumber:
+		if field.Kind != "int" && field.Kind != "float" {
+			return formField{}, false, fmt.Errorf("%s input requires a number", field.Type)
+		}
+	case field.Type == InputCheckbox:
+		if field.Kind != "bool" {
+			return formField{}, false, fmt.Errorf("%s input requires a bool", field.Type)
+		}
+	case field.Type == InputSelect:
+		if len(field.Options) == 0 {
+			return formField{}, false, fmt.Errorf("%s input requires options", field.Type)
+		}
+		if field.Kind == "bool" {
+			return formField{}, false, fmt.Errorf("%s input requires a string or a number", field.Type)
+		}
+	default:
+		return formField{}, false, fmt.Errorf("unknown input type %q", field.Type)
+	}
+
+	return field, true, nil
+}
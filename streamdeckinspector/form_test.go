@@ -0,0 +1,196 @@
+package streamdeckinspector_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckinspector"
+)
+
+type (
+	textSettings struct {
+		Name string `json:"name" inspector:"Name"`
+	}
+	intSettings struct {
+		Step int `json:"step" inspector:"Step,min=1,max=10,step=1"`
+	}
+	uintSettings struct {
+		Count uint8 `json:"count" inspector:"Count"`
+	}
+	floatSettings struct {
+		Scale float64 `json:"scale" inspector:"Scale,step=0.5"`
+	}
+	checkboxSettings struct {
+		Enabled bool `json:"enabled" inspector:"Enabled"`
+	}
+	selectSettings struct {
+		Mode string `json:"mode" inspector:"Mode,type=select,options=up:Up|down"`
+	}
+	colorSettings struct {
+		Color string `json:"color" inspector:"Color,type=color"`
+	}
+	defaultSettings struct {
+		Title   string
+		Hidden  string `json:"-"`
+		Skipped string `json:"skipped" inspector:"-"`
+		Tags    []string
+		private string
+	}
+	unsupportedSettings struct {
+		Tags []string `json:"tags" inspector:"Tags"`
+	}
+	mismatchedSettings struct {
+		Count int `json:"count" inspector:"Count,type=checkbox"`
+	}
+	unknownTypeSettings struct {
+		Name string `json:"name" inspector:"Name,type=range"`
+	}
+	unknownOptionSettings struct {
+		Name string `json:"name" inspector:"Name,size=3"`
+	}
+	optionlessSelectSettings struct {
+		Mode string `json:"mode" inspector:"Mode,type=select"`
+	}
+)
+
+func TestForm(t *testing.T) {
+	testCases := []struct {
+		name string
+		form func() (*streamdeckinspector.Inspector, error)
+		want []string
+		err  string
+	}{
+		{
+			name: "text",
+			form: streamdeckinspector.Form[textSettings],
+			want: []string{
+				`<label class="sdpi-item-label" for="name">Name</label>`,
+				`<input class="sdpi-item-value" type="text" id="name" name="name" data-kind="string">`,
+			},
+		},
+		{
+			name: "int",
+			form: streamdeckinspector.Form[intSettings],
+			want: []string{`type="number" id="step" name="step" data-kind="int" min="1" max="10" step="1">`},
+		},
+		{
+			name: "uint",
+			form: streamdeckinspector.Form[uintSettings],
+			want: []string{`type="number" id="count" name="count" data-kind="int">`},
+		},
+		{
+			name: "float",
+			form: streamdeckinspector.Form[floatSettings],
+			want: []string{`type="number" id="scale" name="scale" data-kind="float" step="0.5">`},
+		},
+		{
+			name: "checkbox",
+			form: streamdeckinspector.Form[checkboxSettings],
+			want: []string{`type="checkbox" id="enabled" name="enabled" data-kind="bool">`},
+		},
+		{
+			name: "select",
+			form: streamdeckinspector.Form[selectSettings],
+			want: []string{
+				`<select class="sdpi-item-value" id="mode" name="mode" data-kind="string">`,
+				`<option value="up">Up</option>`,
+				`<option value="down">down</option>`,
+			},
+		},
+		{
+			name: "color",
+			form: streamdeckinspector.Form[colorSettings],
+			want: []string{`type="color" id="color" name="color" data-kind="string">`},
+		},
+		{
+			name: "defaults",
+			form: streamdeckinspector.Form[defaultSettings],
+			want: []string{
+				`<title>defaultSettings</title>`,
+				`<label class="sdpi-item-label" for="Title">Title</label>`,
+				`type="text" id="Title" name="Title" data-kind="string">`,
+			},
+		},
+		{
+			name: "unsupported kind",
+			form: streamdeckinspector.Form[unsupportedSettings],
+			err:  "field Tags of streamdeckinspector_test.unsupportedSettings: unsupported kind slice",
+		},
+		{
+			name: "mismatched type",
+			form: streamdeckinspector.Form[mismatchedSettings],
+			err:  "checkbox input requires a bool",
+		},
+		{
+			name: "unknown type",
+			form: streamdeckinspector.Form[unknownTypeSettings],
+			err:  `unknown input type "range"`,
+		},
+		{
+			name: "unknown option",
+			form: streamdeckinspector.Form[unknownOptionSettings],
+			err:  `unknown option "size"`,
+		},
+		{
+			name: "select without options",
+			form: streamdeckinspector.Form[optionlessSelectSettings],
+			err:  "select input requires options",
+		},
+		{
+			name: "not a struct",
+			form: streamdeckinspector.Form[string],
+			err:  "settings type string is not a struct",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inspector, err := tc.form()
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			page := readPage(t, inspector)
+			for _, want := range tc.want {
+				if !strings.Contains(page, want) {
+					t.Errorf("expected the form to contain %s, got\n%s", want, page)
+				}
+			}
+		})
+	}
+}
+
+func TestFormSkipsFields(t *testing.T) {
+	page := readPage(t, streamdeckinspector.MustForm[defaultSettings]())
+
+	// Untagged fields of unsupported kinds are skipped, like unexported fields and those tagged "-".
+	for _, name := range []string{"Hidden", "skipped", "Skipped", "Tags", "private"} {
+		if strings.Contains(page, `name="`+name+`"`) {
+			t.Errorf("expected no input for %s, got\n%s", name, page)
+		}
+	}
+}
+
+func readPage(t *testing.T, inspector *streamdeckinspector.Inspector) string {
+	t.Helper()
+
+	f, err := inspector.Open(streamdeckinspector.FormPage)
+	if err != nil {
+		t.Fatalf("opening %s: %v", streamdeckinspector.FormPage, err)
+	}
+	defer f.Close()
+
+	page, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("reading %s: %v", streamdeckinspector.FormPage, err)
+	}
+
+	return string(page)
+}
@@ -0,0 +1,106 @@
+package streamdeckinspector
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+var _ fs.FS = (*Inspector)(nil)
+
+// New makes an Inspector from hand-written files, typically embedded with an embed.FS. The root of files is the
+// directory of the property inspector; use fs.Sub to drop the directories embed.FS keeps.
+func New(files fs.FS) *Inspector {
+	return &Inspector{
+		files:     files,
+		generated: make(map[string][]byte),
+	}
+}
+
+// Inspector holds the files of the property inspector of an action, along with the scripts in Assets. It implements
+// the streamdeckmanifest.Assets interface, so assigning it to streamdeckmanifest.Action.PropertyInspector writes it
+// alongside the generated manifest, and serves its files as an fs.FS, for instance to preview it with
+// http.FileServer. Generated files, such as the page of a Form, are served but not listed in directories.
+type Inspector struct {
+	files     fs.FS
+	generated map[string][]byte
+}
+
+// Open implements the fs.FS interface. Generated files and Assets take precedence over the files of the Inspector.
+func (i *Inspector) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if data, ok := i.generated[name]; ok {
+		return &memFile{name: path.Base(name), Reader: bytes.NewReader(data)}, nil
+	}
+
+	// The root is the directory of the files, which Assets only add to.
+	if name != "." || i.files == nil {
+		if f, err := Assets.Open(name); err == nil {
+			return f, nil
+		}
+	}
+
+	if i.files == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return i.files.Open(name)
+}
+
+// WriteAssets writes the files of the Inspector into dir, the directory of its page.
+func (i *Inspector) WriteAssets(dir string) error {
+	if i.files != nil {
+		if err := writeFS(i.files, dir); err != nil {
+			return err
+		}
+	}
+
+	if err := WriteAssets(dir); err != nil {
+		return err
+	}
+
+	for name, data := range i.generated {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", target, err)
+		}
+	}
+
+	return nil
+}
+
+// memFile is a generated file opened from an Inspector.
+type memFile struct {
+	*bytes.Reader
+	name string
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	return memFileInfo{name: f.name, size: f.Size()}, nil
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+type memFileInfo struct {
+	name string
+	size int64
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return fi.size }
+func (fi memFileInfo) Mode() fs.FileMode  { return 0444 }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return false }
+func (fi memFileInfo) Sys() interface{}   { return nil }
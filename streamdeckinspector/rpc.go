@@ -0,0 +1,150 @@
+package streamdeckinspector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+var _ streamdeck.SendToPluginHandler = (*Router)(nil)
+
+// Error is the error replied to a request. Handlers may return an *Error to control the Code and Data seen by the
+// property inspector; other errors are replied with their message alone.
+type Error struct {
+	Code    string          `json:"code,omitempty"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+const (
+	// CodeUnknownMethod is the Code of the Error replied to requests for a method without a handler.
+	CodeUnknownMethod = "unknown_method"
+	// CodeInvalidParams is the Code of the Error replied to requests whose params can't be decoded.
+	CodeInvalidParams = "invalid_params"
+)
+
+// message is the payload of the streamdeckevent.SendToPlugin and streamdeckevent.SendToPropertyInspector events
+// exchanged by a Router and the property inspector. Requests have an ID and expect a reply with the same ID, carrying
+// either a Result or an Error. Notifications have a Method but no ID, and expect no reply.
+type message struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+type handlerFunc func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// NewRouter makes a Router replying to the property inspector of the instance through the publisher.
+func NewRouter(publisher streamdeck.ActionInstancePublisher) *Router {
+	return &Router{
+		publisher: publisher,
+		handlers:  make(map[string]handlerFunc),
+	}
+}
+
+// Router dispatches the requests a property inspector sends with the streamdeck-rpc.js helper to the handlers
+// registered with On, and replies with their results. It implements streamdeck.SendToPluginHandler, so an
+// ActionInstance can embed it to route the streamdeckevent.SendToPlugin events it receives. Messages that are not
+// requests or notifications are ignored. It is safe for concurrent use.
+type Router struct {
+	publisher streamdeck.ActionInstancePublisher
+
+	mu       sync.RWMutex
+	handlers map[string]handlerFunc
+}
+
+// On registers the handler of the method, replacing any previous handler. The params of a request are decoded into
+// Req and the returned Resp is replied as its result; a returned error is replied instead.
+func On[Req any, Resp any](r *Router, method string, handler func(ctx context.Context, req Req) (Resp, error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[method] = func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var req Req
+		if len(params) > 0 && string(params) != "null" {
+			if err := json.Unmarshal(params, &req); err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Notify sends a notification to the property inspector, delivered to the listener it registered for the method.
+func (r *Router) Notify(method string, params interface{}) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("marshalling params of %s: %w", method, err)
+	}
+
+	return r.send(message{Method: method, Params: raw})
+}
+
+// HandleSendToPlugin implements the streamdeck.SendToPluginHandler interface. Errors returned by handlers are replied
+// to the property inspector rather than returned; only failures to reply are.
+func (r *Router) HandleSendToPlugin(ctx context.Context, event streamdeckevent.SendToPlugin) error {
+	var msg message
+	if err := json.Unmarshal(event.Payload, &msg); err != nil || msg.Method == "" {
+		return nil
+	}
+
+	r.mu.RLock()
+	handler, ok := r.handlers[msg.Method]
+	r.mu.RUnlock()
+
+	var (
+		result interface{}
+		err    error
+	)
+	if ok {
+		result, err = handler(ctx, msg.Params)
+	} else {
+		err = &Error{Code: CodeUnknownMethod, Message: fmt.Sprintf("unknown method %q", msg.Method)}
+	}
+
+	if len(msg.ID) == 0 {
+		return nil
+	}
+
+	reply := message{ID: msg.ID}
+	if err != nil {
+		var replyErr *Error
+		if !errors.As(err, &replyErr) {
+			replyErr = &Error{Message: err.Error()}
+		}
+		reply.Error = replyErr
+	} else if reply.Result, err = json.Marshal(result); err != nil {
+		reply.Error = &Error{Message: fmt.Sprintf("marshalling result: %v", err)}
+	}
+
+	if err = r.send(reply); err != nil {
+		return fmt.Errorf("replying to %s: %w", msg.Method, err)
+	}
+
+	return nil
+}
+
+func (r *Router) send(msg message) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshalling message: %w", err)
+	}
+
+	return r.publisher.SendToPropertyInspector(raw)
+}
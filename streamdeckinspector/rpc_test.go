@@ -0,0 +1,166 @@
+package streamdeckinspector_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckinspector"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+)
+
+type addParams struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+func TestRouter(t *testing.T) {
+	// The message of an invalid params error is that of the failure to decode them.
+	var params addParams
+	decodeErr := json.Unmarshal([]byte(`{"a":"one"}`), &params)
+
+	testCases := []struct {
+		name    string
+		payload string
+		calls   int32
+		replies []string
+	}{
+		{
+			name:    "request",
+			payload: `{"id":7,"method":"add","params":{"a":1,"b":2}}`,
+			calls:   1,
+			replies: []string{`{"id":7,"result":3}`},
+		},
+		{
+			name:    "request with string id",
+			payload: `{"id":"abc","method":"add","params":{"a":2,"b":2}}`,
+			calls:   1,
+			replies: []string{`{"id":"abc","result":4}`},
+		},
+		{
+			name:    "notification",
+			payload: `{"method":"add","params":{"a":1,"b":2}}`,
+			calls:   1,
+		},
+		{
+			name:    "unknown method",
+			payload: `{"id":1,"method":"subtract","params":{"a":1,"b":2}}`,
+			replies: []string{`{"id":1,"error":{"code":"unknown_method","message":"unknown method \"subtract\""}}`},
+		},
+		{
+			name:    "notification of unknown method",
+			payload: `{"method":"subtract"}`,
+		},
+		{
+			name:    "invalid params",
+			payload: `{"id":2,"method":"add","params":{"a":"one"}}`,
+			replies: []string{fmt.Sprintf(`{"id":2,"error":{"code":"invalid_params","message":%q}}`, decodeErr)},
+		},
+		{
+			name:    "error",
+			payload: `{"id":3,"method":"fail"}`,
+			calls:   1,
+			replies: []string{`{"id":3,"error":{"message":"out of order"}}`},
+		},
+		{
+			name:    "coded error",
+			payload: `{"id":4,"method":"deny"}`,
+			calls:   1,
+			replies: []string{`{"id":4,"error":{"code":"denied","message":"not allowed","data":{"retry":false}}}`},
+		},
+		{
+			name:    "not a message",
+			payload: `"hello"`,
+		},
+		{
+			name:    "reply",
+			payload: `{"id":5,"result":true}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := streamdecktest.NewRecorder()
+			router := streamdeckinspector.NewRouter(recorder.ActionInstancePublisher("com.example.action", "key"))
+
+			var calls atomic.Int32
+			streamdeckinspector.On(router, "add", func(_ context.Context, req addParams) (int, error) {
+				calls.Add(1)
+				return req.A + req.B, nil
+			})
+			streamdeckinspector.On(router, "fail", func(_ context.Context, _ struct{}) (interface{}, error) {
+				calls.Add(1)
+				return nil, errors.New("out of order")
+			})
+			streamdeckinspector.On(router, "deny", func(_ context.Context, _ struct{}) (interface{}, error) {
+				calls.Add(1)
+				return nil, &streamdeckinspector.Error{Code: "denied", Message: "not allowed", Data: json.RawMessage(`{"retry":false}`)}
+			})
+
+			err := router.HandleSendToPlugin(context.Background(), streamdeckevent.SendToPlugin{
+				Action:  "com.example.action",
+				Event:   streamdeckevent.SendToPluginName,
+				Context: "key",
+				Payload: json.RawMessage(tc.payload),
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if got := calls.Load(); got != tc.calls {
+				t.Fatalf("expected %d calls to handlers, got %d", tc.calls, got)
+			}
+
+			var replies []string
+			for _, event := range recorder.Events() {
+				if e, ok := event.(streamdeckevent.SendToPropertyInspector); ok {
+					replies = append(replies, string(e.Payload))
+				}
+			}
+			assertJSONs(t, replies, tc.replies)
+		})
+	}
+}
+
+func TestRouterNotify(t *testing.T) {
+	recorder := streamdecktest.NewRecorder()
+	router := streamdeckinspector.NewRouter(recorder.ActionInstancePublisher("com.example.action", "key"))
+
+	if err := router.Notify("progress", map[string]int{"percent": 50}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var sent []string
+	for _, event := range recorder.Events() {
+		if e, ok := event.(streamdeckevent.SendToPropertyInspector); ok {
+			sent = append(sent, string(e.Payload))
+		}
+	}
+	assertJSONs(t, sent, []string{`{"method":"progress","params":{"percent":50}}`})
+}
+
+func assertJSONs(t *testing.T, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for i := range got {
+		var g, w interface{}
+		if err := json.Unmarshal([]byte(got[i]), &g); err != nil {
+			t.Fatalf("unmarshalling %s: %v", got[i], err)
+		}
+		if err := json.Unmarshal([]byte(want[i]), &w); err != nil {
+			t.Fatalf("unmarshalling %s: %v", want[i], err)
+		}
+		if !reflect.DeepEqual(g, w) {
+			t.Fatalf("expected %s, got %s", want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package streamdeckmanifest
+
+import (
+	"path/filepath"
+)
+
+// imageExtensions are the files an image path without an extension may refer to, as resolved by the application.
+var imageExtensions = []string{".png", "@2x.png", ".svg"}
+
+// Images returns the paths of the images the manifest refers to, relative to the directory of the plugin. Paths are
+// usually given without an extension; ImageFiles resolves them to files.
+func (m Manifest) Images() []string {
+	var paths []string
+	add := func(path string) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	add(m.Icon)
+	add(m.CategoryIcon)
+	for _, action := range m.Actions {
+		add(action.Icon)
+		for _, state := range action.States {
+			add(state.Image)
+			add(state.MultiActionImage)
+		}
+		if action.Encoder != nil {
+			add(action.Encoder.Icon)
+			add(action.Encoder.Background)
+		}
+	}
+
+	return paths
+}
+
+// PropertyInspectors returns the paths of the property inspectors the manifest refers to, relative to the directory
+// of the plugin.
+func (m Manifest) PropertyInspectors() []string {
+	var paths []string
+	if m.PropertyInspectorPath != "" {
+		paths = append(paths, m.PropertyInspectorPath)
+	}
+	for _, action := range m.Actions {
+		if action.PropertyInspectorPath != "" {
+			paths = append(paths, action.PropertyInspectorPath)
+		}
+	}
+
+	return paths
+}
+
+// ImageFiles returns the files in dir the image path may refer to: the path itself when it has an extension, and
+// otherwise its PNG, high resolution PNG, and SVG variants. The returned paths are relative to dir.
+func ImageFiles(dir string, path string) []string {
+	candidates := []string{path}
+	if filepath.Ext(path) == "" {
+		candidates = candidates[:0]
+		for _, ext := range imageExtensions {
+			candidates = append(candidates, path+ext)
+		}
+	}
+
+	var files []string
+	for _, candidate := range candidates {
+		if exists(filepath.Join(dir, filepath.FromSlash(candidate))) {
+			files = append(files, candidate)
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,9 @@
+// Package streamdeckmanifest describes the manifest.json file of a plugin, so it can be generated from the actions
+// registered in Go rather than kept by hand.
+//
+// Actions describe their entry with streamdeck.WithManifest, or by implementing streamdeck.ManifestDescriber, and
+// streamdeck.Plugin.Manifest completes a base Manifest with those entries. streamdeckutil.ServeWithManifest makes the
+// plugin's own binary write its manifest, for use with go generate:
+//
+//	//go:generate go run . -generate-manifest manifest.json
+package streamdeckmanifest
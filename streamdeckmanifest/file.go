@@ -0,0 +1,43 @@
+package streamdeckmanifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FileName is the name of the manifest file of a plugin.
+const FileName = "manifest.json"
+
+// ReadFile reads the manifest from the file.
+func ReadFile(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var m Manifest
+	if err = json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("unmarshalling manifest %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// WriteFile writes the manifest to the file as indented JSON.
+func WriteFile(path string, m Manifest) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		return fmt.Errorf("marshalling manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	return nil
+}
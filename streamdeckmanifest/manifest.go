@@ -0,0 +1,121 @@
+package streamdeckmanifest
+
+import (
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// Manifest describes a plugin to the application. It is written as the manifest.json file of the plugin.
+type Manifest struct {
+	Actions               []Action               `json:"Actions"`
+	ApplicationsToMonitor *ApplicationsToMonitor `json:"ApplicationsToMonitor,omitempty"`
+	Author                string                 `json:"Author"`
+	Category              string                 `json:"Category,omitempty"`
+	CategoryIcon          string                 `json:"CategoryIcon,omitempty"`
+	CodePath              string                 `json:"CodePath,omitempty"`
+	CodePathMac           string                 `json:"CodePathMac,omitempty"`
+	CodePathWin           string                 `json:"CodePathWin,omitempty"`
+	DefaultWindowSize     []int                  `json:"DefaultWindowSize,omitempty"`
+	Description           string                 `json:"Description"`
+	Icon                  string                 `json:"Icon,omitempty"`
+	Name                  string                 `json:"Name"`
+	OS                    []OS                   `json:"OS"`
+	Profiles              []Profile              `json:"Profiles,omitempty"`
+	PropertyInspectorPath string                 `json:"PropertyInspectorPath,omitempty"`
+	SDKVersion            int                    `json:"SDKVersion"`
+	Software              Software               `json:"Software"`
+	URL                   string                 `json:"URL,omitempty"`
+	UUID                  string                 `json:"UUID,omitempty"`
+	Version               string                 `json:"Version"`
+}
+
+// Action describes an action of the plugin.
+type Action struct {
+	Controllers             []streamdeckevent.Controller `json:"Controllers,omitempty"`
+	DisableAutomaticStates  bool                         `json:"DisableAutomaticStates,omitempty"`
+	DisableCaching          bool                         `json:"DisableCaching,omitempty"`
+	Encoder                 *Encoder                     `json:"Encoder,omitempty"`
+	Icon                    string                       `json:"Icon,omitempty"`
+	Name                    string                       `json:"Name"`
+	PropertyInspectorPath   string                       `json:"PropertyInspectorPath,omitempty"`
+	States                  []State                      `json:"States"`
+	SupportedInMultiActions *bool                        `json:"SupportedInMultiActions,omitempty"`
+	Tooltip                 string                       `json:"Tooltip,omitempty"`
+	UUID                    streamdeckcore.ActionUUID    `json:"UUID"`
+	UserTitleEnabled        *bool                        `json:"UserTitleEnabled,omitempty"`
+	VisibleInActionsList    *bool                        `json:"VisibleInActionsList,omitempty"`
+
+	// PropertyInspector, when set, holds the files of the property inspector. They are written into the directory of
+	// PropertyInspectorPath when the manifest is generated.
+	PropertyInspector Assets `json:"-"`
+}
+
+// Assets are files written into the directory of a plugin when its manifest is generated, such as a
+// streamdeckinspector.Inspector.
+type Assets interface {
+	WriteAssets(dir string) error
+}
+
+// State describes a state of an action. Actions have one state, or two for toggles.
+type State struct {
+	FontFamily       string                            `json:"FontFamily,omitempty"`
+	FontSize         string                            `json:"FontSize,omitempty"`
+	FontStyle        string                            `json:"FontStyle,omitempty"`
+	FontUnderline    bool                              `json:"FontUnderline,omitempty"`
+	Image            string                            `json:"Image,omitempty"`
+	MultiActionImage string                            `json:"MultiActionImage,omitempty"`
+	Name             string                            `json:"Name,omitempty"`
+	ShowTitle        *bool                             `json:"ShowTitle,omitempty"`
+	Title            string                            `json:"Title,omitempty"`
+	TitleAlignment   streamdeckevent.VerticalAlignment `json:"TitleAlignment,omitempty"`
+	TitleColor       streamdeckevent.Color             `json:"TitleColor,omitempty"`
+}
+
+// Encoder describes how an action is displayed on the dials and touchscreen of a Stream Deck +.
+type Encoder struct {
+	Background         string                 `json:"background,omitempty"`
+	Icon               string                 `json:"Icon,omitempty"`
+	Layout             streamdeckevent.Layout `json:"layout,omitempty"`
+	StackColor         streamdeckevent.Color  `json:"StackColor,omitempty"`
+	TriggerDescription *TriggerDescription    `json:"TriggerDescription,omitempty"`
+}
+
+// TriggerDescription describes the interactions of an encoder.
+type TriggerDescription struct {
+	LongTouch string `json:"LongTouch,omitempty"`
+	Push      string `json:"Push,omitempty"`
+	Rotate    string `json:"Rotate,omitempty"`
+	Touch     string `json:"Touch,omitempty"`
+}
+
+// OS describes an operating system the plugin supports.
+type OS struct {
+	MinimumVersion string                  `json:"MinimumVersion"`
+	Platform       streamdeckcore.Platform `json:"Platform"`
+}
+
+// Software describes the version of the application the plugin requires.
+type Software struct {
+	MinimumVersion string `json:"MinimumVersion"`
+}
+
+// Profile describes a profile distributed with the plugin.
+type Profile struct {
+	AutoInstall                 *bool                     `json:"AutoInstall,omitempty"`
+	DeviceType                  streamdeckcore.DeviceType `json:"DeviceType"`
+	DontAutoSwitchWhenInstalled bool                      `json:"DontAutoSwitchWhenInstalled,omitempty"`
+	Name                        string                    `json:"Name"`
+	ReadOnly                    bool                      `json:"ReadOnly,omitempty"`
+}
+
+// ApplicationsToMonitor lists the applications whose launch and termination the plugin is notified of, through the
+// streamdeckevent.ApplicationDidLaunch and streamdeckevent.ApplicationDidTerminate events.
+type ApplicationsToMonitor struct {
+	Mac     []string `json:"mac,omitempty"`
+	Windows []string `json:"windows,omitempty"`
+}
+
+// Bool returns a pointer to v, for the optional flags of the manifest.
+func Bool(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,173 @@
+package streamdeckmanifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pluginDirSuffix is the suffix of the directory a plugin is installed in, named after the plugin's UUID.
+const pluginDirSuffix = ".sdPlugin"
+
+// MaxStates is the number of states an action may have.
+const MaxStates = 2
+
+// uuidPattern matches the reverse-DNS identifiers used for plugins and actions, which may only contain lowercase
+// alphanumeric characters, hyphens, and periods.
+var uuidPattern = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+$`)
+
+// Validate checks that the manifest is complete and consistent. When dir is not empty, it is the directory of the
+// plugin, and the images and property inspectors the manifest refers to must exist in it. Every problem found is
+// reported in the returned error.
+//
+// The UUIDs of the actions must be reverse-DNS identifiers prefixed with the UUID of the plugin. The plugin's UUID is
+// the UUID of the manifest or, without one, the name of dir without its ".sdPlugin" suffix. Without either, the
+// actions need only share a prefix.
+func Validate(m Manifest, dir string) error {
+	v := validator{dir: dir}
+
+	v.required("Author", m.Author)
+	v.required("Description", m.Description)
+	v.required("Name", m.Name)
+	v.required("Version", m.Version)
+	v.required("Software.MinimumVersion", m.Software.MinimumVersion)
+	if m.CodePath == "" && (m.CodePathMac == "" || m.CodePathWin == "") {
+		v.fail("CodePath is required unless both CodePathMac and CodePathWin are set")
+	}
+	if m.SDKVersion == 0 {
+		v.fail("SDKVersion is required")
+	}
+	if len(m.OS) == 0 {
+		v.fail("OS is required")
+	}
+	for i, platform := range m.OS {
+		v.required(fmt.Sprintf("OS[%d].Platform", i), string(platform.Platform))
+		v.required(fmt.Sprintf("OS[%d].MinimumVersion", i), platform.MinimumVersion)
+	}
+
+	v.required("Icon", m.Icon)
+	v.image("Icon", m.Icon)
+	v.image("CategoryIcon", m.CategoryIcon)
+	v.file("PropertyInspectorPath", m.PropertyInspectorPath)
+
+	pluginUUID := m.UUID
+	if pluginUUID == "" && strings.HasSuffix(filepath.Base(dir), pluginDirSuffix) {
+		pluginUUID = strings.TrimSuffix(filepath.Base(dir), pluginDirSuffix)
+	}
+	if pluginUUID != "" && !uuidPattern.MatchString(pluginUUID) {
+		v.fail("plugin UUID %q is not a lowercase reverse-DNS identifier", pluginUUID)
+	}
+
+	if len(m.Actions) == 0 {
+		v.fail("Actions is required")
+	}
+
+	seen := make(map[string]struct{}, len(m.Actions))
+	for i, action := range m.Actions {
+		field := fmt.Sprintf("Actions[%d]", i)
+		uuid := string(action.UUID)
+		if uuid != "" {
+			field = fmt.Sprintf("action %s", uuid)
+		}
+
+		v.required(field+": UUID", uuid)
+		v.required(field+": Name", action.Name)
+		if uuid != "" {
+			if !uuidPattern.MatchString(uuid) {
+				v.fail("%s: UUID is not a lowercase reverse-DNS identifier", field)
+			}
+			if _, ok := seen[uuid]; ok {
+				v.fail("%s: UUID is not unique", field)
+			}
+			seen[uuid] = struct{}{}
+
+			if pluginUUID != "" && !strings.HasPrefix(uuid, pluginUUID+".") {
+				v.fail("%s: UUID is not prefixed with the plugin UUID %s", field, pluginUUID)
+			}
+		}
+
+		if len(action.States) == 0 || len(action.States) > MaxStates {
+			v.fail("%s: has %d states, must have 1 to %d", field, len(action.States), MaxStates)
+		}
+
+		v.required(field+": Icon", action.Icon)
+		v.image(field+": Icon", action.Icon)
+		v.file(field+": PropertyInspectorPath", action.PropertyInspectorPath)
+		for j, state := range action.States {
+			v.required(fmt.Sprintf("%s: States[%d].Image", field, j), state.Image)
+			v.image(fmt.Sprintf("%s: States[%d].Image", field, j), state.Image)
+			v.image(fmt.Sprintf("%s: States[%d].MultiActionImage", field, j), state.MultiActionImage)
+		}
+		if action.Encoder != nil {
+			v.image(field+": Encoder.Icon", action.Encoder.Icon)
+			v.image(field+": Encoder.background", action.Encoder.Background)
+		}
+	}
+
+	if pluginUUID == "" && len(m.Actions) > 1 && commonPrefix(m.Actions) == "" {
+		v.fail("action UUIDs do not share a common prefix")
+	}
+
+	return errors.Join(v.problems...)
+}
+
+// commonPrefix returns the longest reverse-DNS prefix shared by the UUIDs of the actions.
+func commonPrefix(actions []Action) string {
+	prefix := strings.Split(string(actions[0].UUID), ".")
+	for _, action := range actions[1:] {
+		parts := strings.Split(string(action.UUID), ".")
+		n := 0
+		for n < len(prefix) && n < len(parts)-1 && prefix[n] == parts[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	return strings.Join(prefix, ".")
+}
+
+type validator struct {
+	dir      string
+	problems []error
+}
+
+func (v *validator) fail(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Errorf(format, args...))
+}
+
+func (v *validator) required(field string, value string) {
+	if value == "" {
+		v.fail("%s is required", field)
+	}
+}
+
+// image checks that an image exists. Paths are usually given without an extension, letting the application pick the
+// resolution and format.
+func (v *validator) image(field string, path string) {
+	if v.dir == "" || path == "" {
+		return
+	}
+
+	if len(ImageFiles(v.dir, path)) == 0 {
+		v.fail("%s image %s not found", field, path)
+	}
+}
+
+// file checks that a file exists.
+func (v *validator) file(field string, path string) {
+	if v.dir == "" || path == "" {
+		return
+	}
+
+	if !exists(filepath.Join(v.dir, filepath.FromSlash(path))) {
+		v.fail("%s file %s not found", field, path)
+	}
+}
+
+func exists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
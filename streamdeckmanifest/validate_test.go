@@ -0,0 +1,302 @@
+package streamdeckmanifest_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
+)
+
+// validManifest returns a manifest that passes validation, whose images are all named "images/icon".
+func validManifest() streamdeckmanifest.Manifest {
+	return streamdeckmanifest.Manifest{
+		Author:      "Example",
+		Description: "An example plugin.",
+		Name:        "Example",
+		Version:     "1.0.0",
+		UUID:        "com.example.plugin",
+		Icon:        "images/icon",
+		CodePath:    "plugin",
+		SDKVersion:  2,
+		Software:    streamdeckmanifest.Software{MinimumVersion: "6.0"},
+		OS:          []streamdeckmanifest.OS{{Platform: streamdeckcore.PlatformMac, MinimumVersion: "10.15"}},
+		Actions: []streamdeckmanifest.Action{
+			{
+				UUID:   "com.example.plugin.counter",
+				Name:   "Counter",
+				Icon:   "images/icon",
+				States: []streamdeckmanifest.State{{Image: "images/icon"}},
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(m *streamdeckmanifest.Manifest)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(m *streamdeckmanifest.Manifest) {},
+		},
+		{
+			name:   "author",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Author = "" },
+			err:    "Author is required",
+		},
+		{
+			name:   "description",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Description = "" },
+			err:    "Description is required",
+		},
+		{
+			name:   "name",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Name = "" },
+			err:    "Name is required",
+		},
+		{
+			name:   "version",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Version = "" },
+			err:    "Version is required",
+		},
+		{
+			name:   "software",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Software.MinimumVersion = "" },
+			err:    "Software.MinimumVersion is required",
+		},
+		{
+			name:   "code path",
+			modify: func(m *streamdeckmanifest.Manifest) { m.CodePath, m.CodePathMac = "", "plugin" },
+			err:    "CodePath is required unless both CodePathMac and CodePathWin are set",
+		},
+		{
+			name: "code path per platform",
+			modify: func(m *streamdeckmanifest.Manifest) {
+				m.CodePath, m.CodePathMac, m.CodePathWin = "", "plugin", "plugin.exe"
+			},
+		},
+		{
+			name:   "sdk version",
+			modify: func(m *streamdeckmanifest.Manifest) { m.SDKVersion = 0 },
+			err:    "SDKVersion is required",
+		},
+		{
+			name:   "os",
+			modify: func(m *streamdeckmanifest.Manifest) { m.OS = nil },
+			err:    "OS is required",
+		},
+		{
+			name:   "os platform",
+			modify: func(m *streamdeckmanifest.Manifest) { m.OS[0].Platform = "" },
+			err:    "OS[0].Platform is required",
+		},
+		{
+			name:   "os minimum version",
+			modify: func(m *streamdeckmanifest.Manifest) { m.OS[0].MinimumVersion = "" },
+			err:    "OS[0].MinimumVersion is required",
+		},
+		{
+			name:   "icon",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Icon = "" },
+			err:    "Icon is required",
+		},
+		{
+			name:   "plugin uuid",
+			modify: func(m *streamdeckmanifest.Manifest) { m.UUID = "Example" },
+			err:    `plugin UUID "Example" is not a lowercase reverse-DNS identifier`,
+		},
+		{
+			name:   "actions",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions = nil },
+			err:    "Actions is required",
+		},
+		{
+			name:   "action uuid",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].UUID = "" },
+			err:    "Actions[0]: UUID is required",
+		},
+		{
+			name:   "action name",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].Name = "" },
+			err:    "action com.example.plugin.counter: Name is required",
+		},
+		{
+			name:   "action uuid format",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].UUID = "com.example.plugin.Counter" },
+			err:    "action com.example.plugin.Counter: UUID is not a lowercase reverse-DNS identifier",
+		},
+		{
+			name:   "action uuid uniqueness",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions = append(m.Actions, m.Actions[0]) },
+			err:    "action com.example.plugin.counter: UUID is not unique",
+		},
+		{
+			name:   "action uuid prefix",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].UUID = "com.example.counter" },
+			err:    "action com.example.counter: UUID is not prefixed with the plugin UUID com.example.plugin",
+		},
+		{
+			name: "common prefix",
+			modify: func(m *streamdeckmanifest.Manifest) {
+				m.UUID = ""
+				m.Actions = append(m.Actions, m.Actions[0])
+				m.Actions[1].UUID = "org.example.toggle"
+			},
+			err: "action UUIDs do not share a common prefix",
+		},
+		{
+			name: "shared prefix",
+			modify: func(m *streamdeckmanifest.Manifest) {
+				m.UUID = ""
+				m.Actions = append(m.Actions, m.Actions[0])
+				m.Actions[1].UUID = "com.example.plugin.toggle"
+			},
+		},
+		{
+			name:   "no states",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].States = nil },
+			err:    "action com.example.plugin.counter: has 0 states, must have 1 to 2",
+		},
+		{
+			name: "too many states",
+			modify: func(m *streamdeckmanifest.Manifest) {
+				m.Actions[0].States = []streamdeckmanifest.State{{Image: "images/icon"}, {Image: "images/icon"}, {Image: "images/icon"}}
+			},
+			err: "action com.example.plugin.counter: has 3 states, must have 1 to 2",
+		},
+		{
+			name:   "action icon",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].Icon = "" },
+			err:    "action com.example.plugin.counter: Icon is required",
+		},
+		{
+			name:   "state image",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].States[0].Image = "" },
+			err:    "action com.example.plugin.counter: States[0].Image is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := validManifest()
+			tc.modify(&m)
+			assertValidate(t, streamdeckmanifest.Validate(m, ""), tc.err)
+		})
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(m *streamdeckmanifest.Manifest)
+		err    string
+	}{
+		{
+			name:   "present",
+			modify: func(m *streamdeckmanifest.Manifest) {},
+		},
+		{
+			name:   "image with extension",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Icon = "images/icon.png" },
+		},
+		{
+			name:   "icon",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Icon = "images/missing" },
+			err:    "Icon image images/missing not found",
+		},
+		{
+			name:   "category icon",
+			modify: func(m *streamdeckmanifest.Manifest) { m.CategoryIcon = "images/missing" },
+			err:    "CategoryIcon image images/missing not found",
+		},
+		{
+			name:   "property inspector",
+			modify: func(m *streamdeckmanifest.Manifest) { m.PropertyInspectorPath = "pi/missing.html" },
+			err:    "PropertyInspectorPath file pi/missing.html not found",
+		},
+		{
+			name:   "action icon",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].Icon = "images/missing" },
+			err:    "action com.example.plugin.counter: Icon image images/missing not found",
+		},
+		{
+			name:   "action property inspector",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].PropertyInspectorPath = "pi/missing.html" },
+			err:    "action com.example.plugin.counter: PropertyInspectorPath file pi/missing.html not found",
+		},
+		{
+			name:   "state image",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].States[0].Image = "images/missing" },
+			err:    "action com.example.plugin.counter: States[0].Image image images/missing not found",
+		},
+		{
+			name:   "multi-action image",
+			modify: func(m *streamdeckmanifest.Manifest) { m.Actions[0].States[0].MultiActionImage = "images/missing" },
+			err:    "action com.example.plugin.counter: States[0].MultiActionImage image images/missing not found",
+		},
+		{
+			name: "encoder images",
+			modify: func(m *streamdeckmanifest.Manifest) {
+				m.Actions[0].Encoder = &streamdeckmanifest.Encoder{Icon: "images/icon", Background: "images/missing"}
+			},
+			err: "action com.example.plugin.counter: Encoder.background image images/missing not found",
+		},
+		{
+			name:   "plugin uuid from directory",
+			modify: func(m *streamdeckmanifest.Manifest) { m.UUID, m.Actions[0].UUID = "", "com.example.other.counter" },
+			err:    "action com.example.other.counter: UUID is not prefixed with the plugin UUID com.example.plugin",
+		},
+	}
+
+	dir := filepath.Join(t.TempDir(), "com.example.plugin.sdPlugin")
+	if err := os.MkdirAll(filepath.Join(dir, "images"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "images", "icon.png"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := validManifest()
+			tc.modify(&m)
+			assertValidate(t, streamdeckmanifest.Validate(m, dir), tc.err)
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	m := validManifest()
+	m.Author = ""
+	m.Actions[0].Icon = ""
+
+	err := streamdeckmanifest.Validate(m, "")
+	assertValidate(t, err, "Author is required")
+	assertValidate(t, err, "action com.example.plugin.counter: Icon is required")
+}
+
+func assertValidate(t *testing.T, err error, want string) {
+	t.Helper()
+
+	if want == "" {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return
+	}
+
+	if err == nil {
+		t.Fatalf("expected error %q, got none", want)
+	}
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if line == want {
+			return
+		}
+	}
+	t.Fatalf("expected error %q, got %v", want, err)
+}
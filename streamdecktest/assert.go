@@ -0,0 +1,146 @@
+package streamdecktest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// Titles returns every streamdeckevent.SetTitle the plugin published for the context.
+func (h *Host) Titles(eventContext streamdeckcore.EventContext) []streamdeckevent.SetTitle {
+	var events []streamdeckevent.SetTitle
+	for _, raw := range h.ReceivedEvents(streamdeckevent.SetTitleName, eventContext) {
+		var event streamdeckevent.SetTitle
+		if err := json.Unmarshal(raw, &event); err == nil {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// Images returns every streamdeckevent.SetImage the plugin published for the context.
+func (h *Host) Images(eventContext streamdeckcore.EventContext) []streamdeckevent.SetImage {
+	var events []streamdeckevent.SetImage
+	for _, raw := range h.ReceivedEvents(streamdeckevent.SetImageName, eventContext) {
+		var event streamdeckevent.SetImage
+		if err := json.Unmarshal(raw, &event); err == nil {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// Settings returns every streamdeckevent.SetSettings the plugin published for the context.
+func (h *Host) Settings(eventContext streamdeckcore.EventContext) []streamdeckevent.SetSettings {
+	var events []streamdeckevent.SetSettings
+	for _, raw := range h.ReceivedEvents(streamdeckevent.SetSettingsName, eventContext) {
+		var event streamdeckevent.SetSettings
+		if err := json.Unmarshal(raw, &event); err == nil {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// AssertTitle waits up to DefaultTimeout for the latest title published for the context to equal want.
+func (h *Host) AssertTitle(t testing.TB, eventContext streamdeckcore.EventContext, want string) {
+	t.Helper()
+
+	var got []streamdeckevent.SetTitle
+	if !h.eventually(func() bool {
+		got = h.Titles(eventContext)
+		return len(got) > 0 && got[len(got)-1].Payload.Title == want
+	}) {
+		t.Fatalf("expected title %q for context %q, got %v", want, eventContext, got)
+	}
+}
+
+// AssertImage waits up to DefaultTimeout for the latest image published for the context to equal want.
+func (h *Host) AssertImage(t testing.TB, eventContext streamdeckcore.EventContext, want streamdeckevent.Base64String) {
+	t.Helper()
+
+	var got []streamdeckevent.SetImage
+	if !h.eventually(func() bool {
+		got = h.Images(eventContext)
+		return len(got) > 0 && got[len(got)-1].Payload.Image == want
+	}) {
+		t.Fatalf("expected image %q for context %q, got %d images", want, eventContext, len(got))
+	}
+}
+
+// AssertSettings waits up to DefaultTimeout for the latest settings published for the context to be equivalent to
+// want, which is marshalled to JSON for comparison.
+func (h *Host) AssertSettings(t testing.TB, eventContext streamdeckcore.EventContext, want interface{}) {
+	t.Helper()
+
+	wantRaw, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("marshalling expected settings: %v", err)
+	}
+
+	var got []streamdeckevent.SetSettings
+	if !h.eventually(func() bool {
+		got = h.Settings(eventContext)
+		return len(got) > 0 && jsonEqual(got[len(got)-1].Payload, wantRaw)
+	}) {
+		var last json.RawMessage
+		if len(got) > 0 {
+			last = got[len(got)-1].Payload
+		}
+		t.Fatalf("expected settings %s for context %q, got %s", wantRaw, eventContext, last)
+	}
+}
+
+// AssertNoEvent fails if the plugin has published an event with the given name and context. An empty eventContext
+// matches every context.
+func (h *Host) AssertNoEvent(t testing.TB, eventName streamdeckcore.EventName, eventContext streamdeckcore.EventContext) {
+	t.Helper()
+
+	if events := h.ReceivedEvents(eventName, eventContext); len(events) > 0 {
+		t.Fatalf("expected no %q events for context %q, got %d", eventName, eventContext, len(events))
+	}
+}
+
+// eventually waits up to DefaultTimeout for cond to return true.
+func (h *Host) eventually(cond func() bool) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	for {
+		h.mu.Lock()
+		changed := h.changed
+		h.mu.Unlock()
+
+		if cond() {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+// jsonEqual reports whether a and b are equivalent JSON documents, ignoring whitespace and key order.
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+
+	na, _ := json.Marshal(va)
+	nb, _ := json.Marshal(vb)
+	return bytes.Equal(na, nb)
+}
@@ -0,0 +1,36 @@
+// Package streamdecktest provides utilities for testing plugins without the Stream Deck application.
+//
+// A Host is a local websocket server speaking the host side of the protocol. Plugins connect to it through
+// streamdeckcore.Serve using the Config it generates:
+//
+//	host := streamdecktest.NewHost()
+//	defer host.Close()
+//
+//	go streamdeckcore.Serve(ctx, host.Config(), streamdeck.NewPlugin(counter.New()))
+//	_ = host.WaitForRegistration(ctx, 1)
+//
+//	_ = host.Send(streamdecktest.NewWillAppear("com.example.counter", "key", streamdeckevent.WillAppearPayload{}))
+//	_ = host.Send(streamdecktest.NewKeyDown("com.example.counter", "key", streamdeckevent.KeyDownPayload{}))
+//	host.AssertTitle(t, "key", "1")
+//
+// A Recorder unit tests an action without a Host, capturing what its instances publish as typed streamdeckevent
+// structs:
+//
+//	recorder := streamdecktest.NewRecorder()
+//	action := counter.New()
+//	recorder.InitializeAction(action)
+//
+//	_ = action.HandleEvent(ctx, streamdecktest.NewWillAppear("com.example.counter", "key", streamdeckevent.WillAppearPayload{}))
+//	_ = action.HandleEvent(ctx, streamdecktest.NewKeyDown("com.example.counter", "key", streamdeckevent.KeyDownPayload{}))
+//	titles := recorder.Titles("key")
+//
+// AssertManifest validates the manifest of a plugin against its registered actions, after which
+// Host.AssertManifestStates checks that the states the plugin selected exist in it:
+//
+//	plugin := streamdeck.NewPlugin(counter.New())
+//	m := streamdecktest.AssertManifest(t, "manifest.json", plugin)
+//	...
+//	host.AssertManifestStates(t, m)
+//
+// The New* event builders produce raw events suitable for both Host.Send and streamdeck.Plugin.HandleEvent.
+package streamdecktest
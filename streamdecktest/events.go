@@ -0,0 +1,220 @@
+package streamdecktest
+
+import (
+	"encoding/json"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// DeviceUUID is the device the event builders attribute events to.
+const DeviceUUID streamdeckcore.DeviceUUID = "streamdecktest-device"
+
+// NewApplicationDidLaunch builds a raw streamdeckevent.ApplicationDidLaunch.
+func NewApplicationDidLaunch(application string) json.RawMessage {
+	return mustMarshal(streamdeckevent.ApplicationDidLaunch{
+		Event:   streamdeckevent.ApplicationDidLaunchName,
+		Payload: streamdeckevent.ApplicationDidLaunchPayload{Application: application},
+	})
+}
+
+// NewApplicationDidTerminate builds a raw streamdeckevent.ApplicationDidTerminate.
+func NewApplicationDidTerminate(application string) json.RawMessage {
+	return mustMarshal(streamdeckevent.ApplicationDidTerminate{
+		Event:   streamdeckevent.ApplicationDidTerminateName,
+		Payload: streamdeckevent.ApplicationDidTerminatePayload{Application: application},
+	})
+}
+
+// NewDeviceDidConnect builds a raw streamdeckevent.DeviceDidConnect.
+func NewDeviceDidConnect(device streamdeckcore.DeviceUUID, deviceInfo streamdeckevent.DeviceInfo) json.RawMessage {
+	return mustMarshal(streamdeckevent.DeviceDidConnect{
+		Event:      streamdeckevent.DeviceDidConnectName,
+		Device:     device,
+		DeviceInfo: deviceInfo,
+	})
+}
+
+// NewDeviceDidDisconnect builds a raw streamdeckevent.DeviceDidDisconnect.
+func NewDeviceDidDisconnect(device streamdeckcore.DeviceUUID) json.RawMessage {
+	return mustMarshal(streamdeckevent.DeviceDidDisconnect{
+		Event:  streamdeckevent.DeviceDidDisconnectName,
+		Device: device,
+	})
+}
+
+// NewDidReceiveGlobalSettings builds a raw streamdeckevent.DidReceiveGlobalSettings.
+func NewDidReceiveGlobalSettings(settings json.RawMessage) json.RawMessage {
+	return mustMarshal(streamdeckevent.DidReceiveGlobalSettings{
+		Event:   streamdeckevent.DidReceiveGlobalSettingsName,
+		Payload: streamdeckevent.DidReceiveGlobalSettingsPayload{Settings: settings},
+	})
+}
+
+// NewDidReceiveSettings builds a raw streamdeckevent.DidReceiveSettings.
+func NewDidReceiveSettings(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.DidReceiveSettingsPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.DidReceiveSettings{
+		Action:  action,
+		Event:   streamdeckevent.DidReceiveSettingsName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewDialDown builds a raw streamdeckevent.DialDown.
+func NewDialDown(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.DialDownPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.DialDown{
+		Action:  action,
+		Event:   streamdeckevent.DialDownName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewDialPress builds a raw streamdeckevent.DialPress.
+func NewDialPress(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.DialPressPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.DialPress{
+		Action:  action,
+		Event:   streamdeckevent.DialPressName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewDialRotate builds a raw streamdeckevent.DialRotate.
+func NewDialRotate(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.DialRotatePayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.DialRotate{
+		Action:  action,
+		Event:   streamdeckevent.DialRotateName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewDialUp builds a raw streamdeckevent.DialUp.
+func NewDialUp(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.DialUpPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.DialUp{
+		Action:  action,
+		Event:   streamdeckevent.DialUpName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewKeyDown builds a raw streamdeckevent.KeyDown.
+func NewKeyDown(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.KeyDownPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.KeyDown{
+		Action:  action,
+		Event:   streamdeckevent.KeyDownName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewKeyUp builds a raw streamdeckevent.KeyUp.
+func NewKeyUp(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.KeyUpPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.KeyUp{
+		Action:  action,
+		Event:   streamdeckevent.KeyUpName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewPropertyInspectorDidAppear builds a raw streamdeckevent.PropertyInspectorDidAppear.
+func NewPropertyInspectorDidAppear(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext) json.RawMessage {
+	return mustMarshal(streamdeckevent.PropertyInspectorDidAppear{
+		Action:  action,
+		Event:   streamdeckevent.PropertyInspectorDidAppearName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+	})
+}
+
+// NewPropertyInspectorDidDisappear builds a raw streamdeckevent.PropertyInspectorDidDisappear.
+func NewPropertyInspectorDidDisappear(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext) json.RawMessage {
+	return mustMarshal(streamdeckevent.PropertyInspectorDidDisappear{
+		Action:  action,
+		Event:   streamdeckevent.PropertyInspectorDidDisappearName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+	})
+}
+
+// NewSendToPlugin builds a raw streamdeckevent.SendToPlugin.
+func NewSendToPlugin(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload json.RawMessage) json.RawMessage {
+	return mustMarshal(streamdeckevent.SendToPlugin{
+		Action:  action,
+		Event:   streamdeckevent.SendToPluginName,
+		Context: eventContext,
+		Payload: payload,
+	})
+}
+
+// NewSystemDidWakeUp builds a raw streamdeckevent.SystemDidWakeUp.
+func NewSystemDidWakeUp() json.RawMessage {
+	return mustMarshal(streamdeckevent.SystemDidWakeUp{
+		Event: streamdeckevent.SystemDidWakeUpName,
+	})
+}
+
+// NewTitleParametersDidChange builds a raw streamdeckevent.TitleParametersDidChange.
+func NewTitleParametersDidChange(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.TitleParametersDidChangePayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.TitleParametersDidChange{
+		Action:  action,
+		Event:   streamdeckevent.TitleParametersDidChangeName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewTouchTap builds a raw streamdeckevent.TouchTap.
+func NewTouchTap(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.TouchTapPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.TouchTap{
+		Action:  action,
+		Event:   streamdeckevent.TouchTapName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewWillAppear builds a raw streamdeckevent.WillAppear.
+func NewWillAppear(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.WillAppearPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.WillAppear{
+		Action:  action,
+		Event:   streamdeckevent.WillAppearName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// NewWillDisappear builds a raw streamdeckevent.WillDisappear.
+func NewWillDisappear(action streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext, payload streamdeckevent.WillDisappearPayload) json.RawMessage {
+	return mustMarshal(streamdeckevent.WillDisappear{
+		Action:  action,
+		Event:   streamdeckevent.WillDisappearName,
+		Context: eventContext,
+		Device:  DeviceUUID,
+		Payload: payload,
+	})
+}
+
+// mustMarshal marshals the event, which is always possible for the streamdeckevent types.
+func mustMarshal(event interface{}) json.RawMessage {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+
+	return raw
+}
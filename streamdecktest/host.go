@@ -0,0 +1,315 @@
+package streamdecktest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// PluginUUID is the PluginUUID assigned to plugins connecting to a Host.
+	PluginUUID streamdeckcore.PluginUUID = "streamdecktest-plugin"
+	// RegisterEvent is the event plugins connecting to a Host must register with.
+	RegisterEvent streamdeckcore.EventName = "registerPlugin"
+)
+
+// DefaultTimeout is how long the assertion helpers wait for a plugin to publish an expected event.
+var DefaultTimeout = time.Second
+
+var errNotConnected = errors.New("no plugin connected")
+
+// NewHost starts a Host listening on a local port. Call Close when finished.
+func NewHost() *Host {
+	h := &Host{
+		contexts: make(map[streamdeckcore.EventContext]streamdeckcore.ActionUUID),
+		changed:  make(chan struct{}),
+	}
+
+	h.server = httptest.NewServer(http.HandlerFunc(h.serveWebsocket))
+	return h
+}
+
+// Host is a fake Stream Deck application speaking the host side of the websocket protocol. A plugin connects to it
+// using the Config returned by Config, after which tests inject events with Send and inspect everything the plugin
+// publishes.
+type Host struct {
+	server *httptest.Server
+
+	mu            sync.Mutex
+	conn          *websocket.Conn
+	registrations int
+	received      []json.RawMessage
+	contexts      map[streamdeckcore.EventContext]streamdeckcore.ActionUUID
+	err           error
+	changed       chan struct{}
+}
+
+// Config returns the configuration a plugin uses to connect to this Host. Reconnection is disabled, and the
+// registration info describes a single Stream Deck identified by DeviceUUID.
+func (h *Host) Config() *streamdeckcore.Config {
+	_, portString, _ := net.SplitHostPort(h.server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portString)
+
+	return &streamdeckcore.Config{
+		Port:          port,
+		PluginUUID:    PluginUUID,
+		RegisterEvent: RegisterEvent,
+		Info: streamdeckcore.RegistrationInfo{
+			Application: streamdeckcore.ApplicationInfo{
+				Language: "en",
+				Platform: streamdeckcore.PlatformMac,
+				Version:  "6.0.0",
+			},
+			Plugin: streamdeckcore.PluginInfo{
+				UUID:    string(PluginUUID),
+				Version: "1.0.0",
+			},
+			DevicePixelRatio: 1,
+			Devices: []streamdeckcore.RegisteredDevice{{
+				ID: DeviceUUID,
+				DeviceInfo: streamdeckcore.DeviceInfo{
+					Type:       streamdeckcore.StreamDeck,
+					Size:       streamdeckcore.DeviceSize{Columns: 5, Rows: 3},
+					DeviceName: "Stream Deck",
+				},
+			}},
+		},
+	}
+}
+
+// Close disconnects the plugin and stops the Host.
+func (h *Host) Close() {
+	h.mu.Lock()
+	if h.conn != nil {
+		_ = h.conn.Close()
+	}
+	h.mu.Unlock()
+
+	h.server.CloseClientConnections()
+	h.server.Close()
+}
+
+// Disconnect closes the connection to the plugin with the provided close code, as if the host application had quit.
+func (h *Host) Disconnect(code int, text string) error {
+	h.mu.Lock()
+	conn := h.conn
+	h.conn = nil
+	h.mu.Unlock()
+
+	if conn == nil {
+		return errNotConnected
+	}
+
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(time.Second))
+	return conn.Close()
+}
+
+// Err returns the first protocol violation observed from the plugin, such as an invalid registration.
+func (h *Host) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// Registrations returns the number of times a plugin has registered with this Host.
+func (h *Host) Registrations() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.registrations
+}
+
+// WaitForRegistration waits until a plugin has registered the given number of times.
+func (h *Host) WaitForRegistration(ctx context.Context, count int) error {
+	return h.wait(ctx, func() bool {
+		return h.registrations >= count
+	})
+}
+
+// Send marshals the event and sends it to the connected plugin. A json.RawMessage is sent as is.
+func (h *Host) Send(event interface{}) error {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshalling event: %w", err)
+	}
+
+	var header struct {
+		Action  streamdeckcore.ActionUUID   `json:"action"`
+		Context streamdeckcore.EventContext `json:"context"`
+	}
+	_ = json.Unmarshal(raw, &header)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conn == nil {
+		return errNotConnected
+	}
+
+	// Remember the action of each context, so the events the plugin publishes for it can be attributed.
+	if header.Action != "" && header.Context != "" {
+		h.contexts[header.Context] = header.Action
+	}
+
+	if err = h.conn.WriteMessage(websocket.TextMessage, raw); err != nil {
+		return fmt.Errorf("sending event: %w", err)
+	}
+
+	return nil
+}
+
+// Received returns every event published by the plugin, excluding registrations, in the order they were received.
+func (h *Host) Received() []json.RawMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]json.RawMessage(nil), h.received...)
+}
+
+// ReceivedEvents returns the events published by the plugin with the given name and context. An empty eventContext
+// matches every context.
+func (h *Host) ReceivedEvents(eventName streamdeckcore.EventName, eventContext streamdeckcore.EventContext) []json.RawMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return filterEvents(h.received, eventName, eventContext)
+}
+
+// WaitForEvent waits until the plugin has published an event with the given name and context, returning the most
+// recent one. An empty eventContext matches every context.
+func (h *Host) WaitForEvent(ctx context.Context, eventName streamdeckcore.EventName, eventContext streamdeckcore.EventContext) (json.RawMessage, error) {
+	var found json.RawMessage
+	err := h.wait(ctx, func() bool {
+		events := filterEvents(h.received, eventName, eventContext)
+		if len(events) == 0 {
+			return false
+		}
+
+		found = events[len(events)-1]
+		return true
+	})
+
+	return found, err
+}
+
+// wait blocks until cond, which is called while holding the lock, returns true.
+func (h *Host) wait(ctx context.Context, cond func() bool) error {
+	for {
+		h.mu.Lock()
+		ok := cond()
+		changed := h.changed
+		h.mu.Unlock()
+
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (h *Host) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	var upgrader websocket.Upgrader
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	h.mu.Lock()
+	if h.conn != nil {
+		_ = h.conn.Close()
+	}
+	h.conn = conn
+	h.mu.Unlock()
+
+	registered := false
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if !registered {
+			registered = true
+			h.register(msg)
+			continue
+		}
+
+		h.record(msg)
+	}
+}
+
+func (h *Host) register(raw json.RawMessage) {
+	var event struct {
+		PluginUUID streamdeckcore.PluginUUID `json:"uuid"`
+		Event      streamdeckcore.EventName  `json:"event"`
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer h.notify()
+
+	if err := json.Unmarshal(raw, &event); err != nil {
+		h.fail(fmt.Errorf("unmarshalling registration: %w", err))
+		return
+	}
+	if event.Event != RegisterEvent || event.PluginUUID != PluginUUID {
+		h.fail(fmt.Errorf("invalid registration %s", string(raw)))
+		return
+	}
+
+	h.registrations++
+}
+
+func (h *Host) record(raw json.RawMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.received = append(h.received, raw)
+	h.notify()
+}
+
+// fail records the first protocol violation. It must be called while holding the lock.
+func (h *Host) fail(err error) {
+	if h.err == nil {
+		h.err = err
+	}
+}
+
+// notify wakes up any waiters. It must be called while holding the lock.
+func (h *Host) notify() {
+	close(h.changed)
+	h.changed = make(chan struct{})
+}
+
+func filterEvents(events []json.RawMessage, eventName streamdeckcore.EventName, eventContext streamdeckcore.EventContext) []json.RawMessage {
+	var filtered []json.RawMessage
+	for _, raw := range events {
+		var header struct {
+			Event   streamdeckcore.EventName    `json:"event"`
+			Context streamdeckcore.EventContext `json:"context"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			continue
+		}
+
+		if header.Event == eventName && (eventContext == "" || header.Context == eventContext) {
+			filtered = append(filtered, raw)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,149 @@
+package streamdecktest_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdecktest"
+	"github.com/gorilla/websocket"
+)
+
+const pressesUUID streamdeck.ActionUUID = "com.example.presses"
+
+// pressesInstance shows the number of times its key has been pressed and remembers losing the connection.
+type pressesInstance struct {
+	eventContext streamdeck.EventContext
+	publisher    streamdeck.ActionInstancePublisher
+
+	mu           sync.Mutex
+	presses      int
+	disconnected bool
+	reconnected  bool
+}
+
+func (i *pressesInstance) ActionUUID() streamdeck.ActionUUID {
+	return pressesUUID
+}
+
+func (i *pressesInstance) EventContext() streamdeck.EventContext {
+	return i.eventContext
+}
+
+func (i *pressesInstance) HandleKeyDown(_ context.Context, _ streamdeckevent.KeyDown) error {
+	i.mu.Lock()
+	i.presses++
+	presses := i.presses
+	i.mu.Unlock()
+
+	return i.publisher.SetTitle(streamdeckevent.SetTitlePayload{Title: strconv.Itoa(presses)})
+}
+
+func (i *pressesInstance) HandleDisconnected(_ context.Context, _ error) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.disconnected = true
+	return nil
+}
+
+func (i *pressesInstance) HandleReconnected(_ context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.reconnected = true
+	return nil
+}
+
+func TestHost(t *testing.T) {
+	host := streamdecktest.NewHost()
+	defer host.Close()
+
+	var (
+		mu        sync.Mutex
+		instances []*pressesInstance
+	)
+	action := streamdeck.NewInstancedAction(pressesUUID, func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		instance := &pressesInstance{eventContext: eventContext, publisher: publisher}
+		mu.Lock()
+		defer mu.Unlock()
+		instances = append(instances, instance)
+		return instance
+	})
+
+	cfg := host.Config()
+	cfg.Reconnect = &streamdeckcore.Backoff{InitialDelay: 10 * time.Millisecond, MaxDelay: 10 * time.Millisecond, Multiplier: 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() {
+		served <- streamdeckcore.Serve(ctx, cfg, streamdeck.NewPlugin(action))
+	}()
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer waitCancel()
+
+	// Registration.
+	if err := host.WaitForRegistration(waitCtx, 1); err != nil {
+		t.Fatalf("waiting for registration: %v", err)
+	}
+
+	// Events sent to the plugin come back as the events it publishes.
+	send := func(event interface{}) {
+		t.Helper()
+		if err := host.Send(event); err != nil {
+			t.Fatalf("sending event: %v", err)
+		}
+	}
+	send(streamdecktest.NewWillAppear(pressesUUID, "key", streamdeckevent.WillAppearPayload{}))
+	send(streamdecktest.NewKeyDown(pressesUUID, "key", streamdeckevent.KeyDownPayload{}))
+	host.AssertTitle(t, "key", "1")
+	send(streamdecktest.NewKeyDown(pressesUUID, "key", streamdeckevent.KeyDownPayload{}))
+	host.AssertTitle(t, "key", "2")
+
+	// Once the host goes away, the plugin reconnects, registers again, and carries on where it left off.
+	if err := host.Disconnect(websocket.CloseGoingAway, "restarting"); err != nil {
+		t.Fatalf("disconnecting: %v", err)
+	}
+	if err := host.WaitForRegistration(waitCtx, 2); err != nil {
+		t.Fatalf("waiting for the plugin to register again: %v", err)
+	}
+
+	send(streamdecktest.NewWillAppear(pressesUUID, "key", streamdeckevent.WillAppearPayload{}))
+	send(streamdecktest.NewKeyDown(pressesUUID, "key", streamdeckevent.KeyDownPayload{}))
+	host.AssertTitle(t, "key", "3")
+
+	mu.Lock()
+	created := append([]*pressesInstance(nil), instances...)
+	mu.Unlock()
+	if len(created) != 1 {
+		t.Fatalf("expected the instance to survive the reconnection, got %d instances", len(created))
+	}
+	presses := created[0]
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		presses.mu.Lock()
+		disconnected, reconnected := presses.disconnected, presses.reconnected
+		presses.mu.Unlock()
+
+		if disconnected && reconnected {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the instance to see the connection drop and return, got disconnected=%t reconnected=%t", disconnected, reconnected)
+		}
+	}
+
+	if err := host.Err(); err != nil {
+		t.Fatalf("expected the plugin to follow the protocol, got %v", err)
+	}
+
+	cancel()
+	if err := <-served; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Serve to stop once cancelled, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package streamdecktest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckutil"
+)
+
+// AssertManifest validates the manifest at the path against the plugin, as streamdeckutil.ValidateManifest does, and
+// returns it for further checks such as AssertManifestStates.
+func AssertManifest(t testing.TB, path string, plugin *streamdeck.Plugin) streamdeckmanifest.Manifest {
+	t.Helper()
+
+	if err := streamdeckutil.ValidateManifest(path, plugin); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := streamdeckmanifest.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+// AssertManifestStates checks that every streamdeckevent.SetState the plugin published selects a state its action has
+// in the manifest. Contexts are attributed to actions from the events sent with Send, such as the
+// streamdeckevent.WillAppear event that created them.
+func (h *Host) AssertManifestStates(t testing.TB, m streamdeckmanifest.Manifest) {
+	t.Helper()
+
+	states := make(map[streamdeck.ActionUUID]int, len(m.Actions))
+	for _, entry := range m.Actions {
+		states[entry.UUID] = len(entry.States)
+	}
+
+	h.mu.Lock()
+	contexts := make(map[streamdeck.EventContext]streamdeck.ActionUUID, len(h.contexts))
+	for eventContext, actionUUID := range h.contexts {
+		contexts[eventContext] = actionUUID
+	}
+	h.mu.Unlock()
+
+	for _, raw := range h.ReceivedEvents(streamdeckevent.SetStateName, "") {
+		var event streamdeckevent.SetState
+		if err := json.Unmarshal(raw, &event); err != nil {
+			t.Errorf("unmarshalling %s: %v", streamdeckevent.SetStateName, err)
+			continue
+		}
+
+		actionUUID, ok := contexts[event.Context]
+		if !ok {
+			t.Errorf("%s for context %s, which was never sent", streamdeckevent.SetStateName, event.Context)
+			continue
+		}
+
+		if count := states[actionUUID]; event.Payload.State >= count {
+			t.Errorf("action %s: sets state %d but has %d states in the manifest", actionUUID, event.Payload.State, count)
+		}
+	}
+}
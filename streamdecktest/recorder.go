@@ -0,0 +1,179 @@
+package streamdecktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckcore"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+var _ streamdeck.Publisher = (*Recorder)(nil)
+
+// NewRecorder makes a Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Recorder is a streamdeck.Publisher that records every published event as its typed streamdeckevent struct, for
+// instance a streamdeckevent.SetTitle. It backs the publishers returned by ActionPublisher and
+// ActionInstancePublisher so ActionInstances can be unit tested without a Host.
+type Recorder struct {
+	mu     sync.Mutex
+	events []interface{}
+}
+
+// ActionPublisher returns a streamdeck.ActionPublisher for the action that records into this Recorder.
+func (r *Recorder) ActionPublisher(actionUUID streamdeckcore.ActionUUID) streamdeck.ActionPublisher {
+	return streamdeck.NewActionPublisher(PluginUUID, actionUUID, r)
+}
+
+// ActionInstancePublisher returns a streamdeck.ActionInstancePublisher for the action instance that records into this
+// Recorder.
+func (r *Recorder) ActionInstancePublisher(actionUUID streamdeckcore.ActionUUID, eventContext streamdeckcore.EventContext) streamdeck.ActionInstancePublisher {
+	return streamdeck.NewActionInstancePublisher(eventContext, r.ActionPublisher(actionUUID))
+}
+
+// InitializeAction initializes the action with a publisher that records into this Recorder, so its instances can be
+// driven by passing the New* event builders to its HandleEvent.
+func (r *Recorder) InitializeAction(action streamdeck.Action) {
+	action.InitializeAction(PluginUUID, streamdeck.RegistrationInfo{}, r.ActionPublisher(action.ActionUUID()))
+}
+
+// PublishEvent implements the streamdeck.Publisher interface.
+func (r *Recorder) PublishEvent(raw json.RawMessage) error {
+	event, err := DecodeEvent(raw)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+// Events returns every recorded event in the order they were published.
+func (r *Recorder) Events() []interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]interface{}(nil), r.events...)
+}
+
+// Reset discards the recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Titles returns the recorded streamdeckevent.SetTitle events for the context. An empty eventContext matches every
+// context.
+func (r *Recorder) Titles(eventContext streamdeckcore.EventContext) []streamdeckevent.SetTitle {
+	var titles []streamdeckevent.SetTitle
+	for _, event := range r.Events() {
+		if e, ok := event.(streamdeckevent.SetTitle); ok && (eventContext == "" || e.Context == eventContext) {
+			titles = append(titles, e)
+		}
+	}
+
+	return titles
+}
+
+// Images returns the recorded streamdeckevent.SetImage events for the context. An empty eventContext matches every
+// context.
+func (r *Recorder) Images(eventContext streamdeckcore.EventContext) []streamdeckevent.SetImage {
+	var images []streamdeckevent.SetImage
+	for _, event := range r.Events() {
+		if e, ok := event.(streamdeckevent.SetImage); ok && (eventContext == "" || e.Context == eventContext) {
+			images = append(images, e)
+		}
+	}
+
+	return images
+}
+
+// Settings returns the recorded streamdeckevent.SetSettings events for the context. An empty eventContext matches
+// every context.
+func (r *Recorder) Settings(eventContext streamdeckcore.EventContext) []streamdeckevent.SetSettings {
+	var settings []streamdeckevent.SetSettings
+	for _, event := range r.Events() {
+		if e, ok := event.(streamdeckevent.SetSettings); ok && (eventContext == "" || e.Context == eventContext) {
+			settings = append(settings, e)
+		}
+	}
+
+	return settings
+}
+
+// States returns the recorded streamdeckevent.SetState events for the context. An empty eventContext matches every
+// context.
+func (r *Recorder) States(eventContext streamdeckcore.EventContext) []streamdeckevent.SetState {
+	var states []streamdeckevent.SetState
+	for _, event := range r.Events() {
+		if e, ok := event.(streamdeckevent.SetState); ok && (eventContext == "" || e.Context == eventContext) {
+			states = append(states, e)
+		}
+	}
+
+	return states
+}
+
+// DecodeEvent unmarshals an event published by a plugin into its typed streamdeckevent struct. Events that are not
+// known are returned as a json.RawMessage.
+func DecodeEvent(raw json.RawMessage) (interface{}, error) {
+	var header struct {
+		Event streamdeckcore.EventName `json:"event"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, fmt.Errorf("unmarshalling event header: %w", err)
+	}
+
+	var event interface{}
+	switch header.Event {
+	case streamdeckevent.GetGlobalSettingsName:
+		event = &streamdeckevent.GetGlobalSettings{}
+	case streamdeckevent.GetSettingsName:
+		event = &streamdeckevent.GetSettings{}
+	case streamdeckevent.LogMessageName:
+		event = &streamdeckevent.LogMessage{}
+	case streamdeckevent.OpenURLName:
+		event = &streamdeckevent.OpenURL{}
+	case streamdeckevent.SendToPropertyInspectorName:
+		event = &streamdeckevent.SendToPropertyInspector{}
+	case streamdeckevent.SetFeedbackName:
+		event = &streamdeckevent.SetFeedback{}
+	case streamdeckevent.SetFeedbackLayoutName:
+		event = &streamdeckevent.SetFeedbackLayout{}
+	case streamdeckevent.SetGlobalSettingsName:
+		event = &streamdeckevent.SetGlobalSettings{}
+	case streamdeckevent.SetSettingsName:
+		event = &streamdeckevent.SetSettings{}
+	case streamdeckevent.SetImageName:
+		event = &streamdeckevent.SetImage{}
+	case streamdeckevent.SetStateName:
+		event = &streamdeckevent.SetState{}
+	case streamdeckevent.SetTitleName:
+		event = &streamdeckevent.SetTitle{}
+	case streamdeckevent.SetTriggerDescriptionName:
+		event = &streamdeckevent.SetTriggerDescription{}
+	case streamdeckevent.ShowAlertName:
+		event = &streamdeckevent.ShowAlert{}
+	case streamdeckevent.ShowOKName:
+		event = &streamdeckevent.ShowOK{}
+	case streamdeckevent.SwitchToProfileName:
+		event = &streamdeckevent.SwitchToProfile{}
+	default:
+		return append(json.RawMessage(nil), raw...), nil
+	}
+
+	if err := json.Unmarshal(raw, event); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %w", header.Event, err)
+	}
+
+	// Return the struct rather than a pointer so callers can type switch on the streamdeckevent types.
+	return reflect.ValueOf(event).Elem().Interface(), nil
+}
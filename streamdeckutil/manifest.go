@@ -0,0 +1,87 @@
+package streamdeckutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckmanifest"
+)
+
+const (
+	// GenerateManifestFlag is the argument making ServeWithManifest write the manifest instead of serving the plugin.
+	GenerateManifestFlag = "-generate-manifest"
+	// ValidateManifestFlag is the argument making ServeWithManifest validate the manifest instead of serving the
+	// plugin.
+	ValidateManifestFlag = "-validate-manifest"
+)
+
+// ServeWithManifest is like ServePlugin, except that when the arguments are GenerateManifestFlag or
+// ValidateManifestFlag followed by a path, it generates or validates the manifest at the path and returns. This lets
+// a plugin generate its manifest from its own list of actions, and check an existing manifest against them:
+//
+//	//go:generate go run . -generate-manifest manifest.json
+//
+//	go run . -validate-manifest com.example.plugin.sdPlugin/manifest.json
+func ServeWithManifest(ctx context.Context, args []string, plugin *streamdeck.Plugin, base streamdeckmanifest.Manifest) error {
+	if len(args) > 1 && (args[1] == GenerateManifestFlag || args[1] == ValidateManifestFlag) {
+		if len(args) != 3 {
+			return fmt.Errorf("usage: %s %s <path>", args[0], args[1])
+		}
+
+		if args[1] == ValidateManifestFlag {
+			return ValidateManifest(args[2], plugin)
+		}
+
+		return GenerateManifest(args[2], plugin, base)
+	}
+
+	return ServePlugin(ctx, args, plugin)
+}
+
+// GenerateManifest writes the manifest generated from base and the plugin's actions to the path. The property
+// inspectors of the entries are written alongside it, into the directories of their PropertyInspectorPath.
+func GenerateManifest(path string, plugin *streamdeck.Plugin, base streamdeckmanifest.Manifest) error {
+	m, err := plugin.Manifest(base)
+	if err != nil {
+		return fmt.Errorf("generating manifest: %w", err)
+	}
+
+	if err = streamdeckmanifest.WriteFile(path, m); err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	for _, entry := range m.Actions {
+		if entry.PropertyInspector == nil {
+			continue
+		}
+		if entry.PropertyInspectorPath == "" {
+			return fmt.Errorf("action %s has a property inspector but no PropertyInspectorPath", entry.UUID)
+		}
+
+		inspectorDir := filepath.Join(dir, filepath.Dir(filepath.FromSlash(entry.PropertyInspectorPath)))
+		if err = entry.PropertyInspector.WriteAssets(inspectorDir); err != nil {
+			return fmt.Errorf("writing property inspector of action %s: %w", entry.UUID, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateManifest reads the manifest at the path and validates it with streamdeckmanifest.Validate, using the
+// directory of the manifest as the directory of the plugin, and with streamdeck.Plugin.ValidateManifest.
+func ValidateManifest(path string, plugin *streamdeck.Plugin) error {
+	m, err := streamdeckmanifest.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err = errors.Join(streamdeckmanifest.Validate(m, filepath.Dir(path)), plugin.ValidateManifest(m)); err != nil {
+		return fmt.Errorf("invalid manifest %s:\n%w", path, err)
+	}
+
+	return nil
+}
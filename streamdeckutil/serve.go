@@ -13,13 +13,17 @@ import (
 // Serve is a helper method for launching a plugin. It parse the arguments and listens for the os.Interrupt event
 // to shutdown.
 func Serve(ctx context.Context, args []string, actions ...streamdeck.Action) error {
+	return ServePlugin(ctx, args, streamdeck.NewPlugin(actions...))
+}
+
+// ServePlugin is like Serve, but serves an already configured plugin, such as a streamdeck.Plugin with attached
+// components.
+func ServePlugin(ctx context.Context, args []string, plugin streamdeckcore.Plugin) error {
 	cfg, err := streamdeckcore.ParseConfig(args)
 	if err != nil {
 		return fmt.Errorf("parsing config args: %w", err)
 	}
 
-	plugin := streamdeck.NewPlugin(actions...)
-
 	ctx, cancel := context.WithCancel(ctx)
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
@@ -0,0 +1,46 @@
+package streamdeck
+
+import (
+	"sync"
+)
+
+// subscribers holds the callbacks registered to be notified of changes to a T. It is safe for concurrent use.
+type subscribers[T any] struct {
+	mu   sync.Mutex
+	subs []*subscriber[T]
+}
+
+type subscriber[T any] struct {
+	fn func(T)
+}
+
+// add registers fn and returns a func removing it.
+func (s *subscribers[T]) add(fn func(T)) func() {
+	sub := &subscriber[T]{fn: fn}
+
+	s.mu.Lock()
+	s.subs = append(s.subs, sub)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, existing := range s.subs {
+			if existing == sub {
+				s.subs = append(s.subs[:i:i], s.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify calls every registered callback with the value, without holding the lock so callbacks may unsubscribe.
+func (s *subscribers[T]) notify(value T) {
+	s.mu.Lock()
+	subs := s.subs
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.fn(value)
+	}
+}
@@ -16,5 +16,9 @@ type EventContext = streamdeckcore.EventContext
 // EventName is the name of an event. It is an alias for streamdeckcore.EventName.
 type EventName = streamdeckcore.EventName
 
+// RegistrationInfo describes the application, the plugin, and the connected devices at the time the plugin was
+// launched. It is an alias for streamdeckcore.RegistrationInfo.
+type RegistrationInfo = streamdeckcore.RegistrationInfo
+
 // PluginUUID is the unique identifier assigned to a plugin by a device. It is an alias for streamdeckcore.PluginUUID.
 type PluginUUID = streamdeckcore.PluginUUID
@@ -0,0 +1,67 @@
+package streamdeck
+
+import (
+	"sync"
+
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+// visualCache remembers the last titles and images published for an action instance, so they can be replayed when
+// the instance appears again. The state is left out, since the application keeps track of it, toggling it as a key
+// with multiple states is pressed, and reports it when the instance appears. It is safe for concurrent use.
+type visualCache struct {
+	mu     sync.Mutex
+	titles []streamdeckevent.SetTitlePayload
+	images []streamdeckevent.SetImagePayload
+}
+
+func (c *visualCache) setTitle(payload streamdeckevent.SetTitlePayload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.titles = rememberPayload(c.titles, payload, func(p streamdeckevent.SetTitlePayload) *int { return p.State })
+}
+
+func (c *visualCache) setImage(payload streamdeckevent.SetImagePayload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.images = rememberPayload(c.images, payload, func(p streamdeckevent.SetImagePayload) *int { return p.State })
+}
+
+// replay publishes the remembered titles and images in the order they were published.
+func (c *visualCache) replay(publisher ActionInstancePublisher) error {
+	c.mu.Lock()
+	titles := append([]streamdeckevent.SetTitlePayload(nil), c.titles...)
+	images := append([]streamdeckevent.SetImagePayload(nil), c.images...)
+	c.mu.Unlock()
+
+	for _, title := range titles {
+		if err := publisher.SetTitle(title); err != nil {
+			return err
+		}
+	}
+
+	for _, image := range images {
+		if err := publisher.SetImage(image); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rememberPayload adds the payload to those remembered, dropping the ones it overrides. A payload without a state
+// applies to every state and so overrides them all.
+func rememberPayload[T any](payloads []T, payload T, state func(T) *int) []T {
+	if state(payload) == nil {
+		return append(payloads[:0], payload)
+	}
+
+	remembered := payloads[:0]
+	for _, p := range payloads {
+		if s := state(p); s == nil || *s != *state(payload) {
+			remembered = append(remembered, p)
+		}
+	}
+
+	return append(remembered, payload)
+}
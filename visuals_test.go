@@ -0,0 +1,35 @@
+package streamdeck_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/go-streamdeck-sdk"
+	"github.com/craiggwilson/go-streamdeck-sdk/streamdeckevent"
+)
+
+func TestReplayLeavesStateToTheApplication(t *testing.T) {
+	action := streamdeck.NewInstancedAction(testActionUUID, func(eventContext streamdeck.EventContext, publisher streamdeck.ActionInstancePublisher) streamdeck.ActionInstance {
+		return &stateInstance{eventContext: eventContext, publisher: publisher}
+	})
+	host, _ := servePlugin(t, streamdeck.NewPlugin(action))
+
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, keyDown("key", 0))
+	waitUntil(t, func() bool {
+		return len(host.ReceivedEvents(streamdeckevent.SetStateName, "key")) == 1
+	})
+
+	// The key is toggled back to state 0 when released, so replaying state 1 once it appears again would be stale.
+	mustSend(t, host, keyUp("key", 0))
+	mustSend(t, host, willDisappear("key"))
+	mustSend(t, host, willAppear("key"))
+	mustSend(t, host, willAppear("probe"))
+	waitUntil(t, func() bool {
+		_, ok := action.Instance("probe")
+		return ok
+	})
+
+	if got := host.ReceivedEvents(streamdeckevent.SetStateName, "key"); len(got) != 1 {
+		t.Fatalf("expected the state not to be replayed, got %d setState events", len(got))
+	}
+}